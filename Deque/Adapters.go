@@ -0,0 +1,137 @@
+package Deque
+
+// AsStack returns an adapter which exposes the deque through the Stack.Stack() method set
+// the back of the deque is the top of the stack
+// the adapter shares the data with the deque, so changes through one are seen by the other
+func (dq *dequeStruct) AsStack() *stackAdapter {
+	return &stackAdapter{dq: dq}
+}
+
+// AsQueue returns an adapter which exposes the deque through the Queue.Queue() method set
+// the front of the deque is the front of the queue and elements are pushed to the back
+// the adapter shares the data with the deque, so changes through one are seen by the other
+func (dq *dequeStruct) AsQueue() *queueAdapter {
+	return &queueAdapter{dq: dq}
+}
+
+// stackAdapter a deque seen as a stack
+type stackAdapter struct {
+	dq *dequeStruct
+}
+
+func (sa *stackAdapter) Push(elem ...interface{}) error {
+	return sa.dq.PushBack(elem...)
+}
+
+func (sa *stackAdapter) Pop() error {
+	return sa.dq.PopBack()
+}
+
+func (sa *stackAdapter) Pops(popCount int) error {
+	return sa.dq.PopBacks(popCount)
+}
+
+func (sa *stackAdapter) RemoveAll() {
+	sa.dq.RemoveAll()
+}
+
+func (sa *stackAdapter) Clear() {
+	sa.dq.Clear()
+}
+
+func (sa *stackAdapter) Top() (interface{}, error) {
+	return sa.dq.Back()
+}
+
+func (sa *stackAdapter) Tops(topCount int) ([]interface{}, error) {
+	return sa.dq.Backs(topCount)
+}
+
+func (sa *stackAdapter) TopAndPop() (interface{}, error) {
+	return sa.dq.BackAndPop()
+}
+
+func (sa *stackAdapter) TopsAndPops(count int) ([]interface{}, error) {
+	return sa.dq.BacksAndPops(count)
+}
+
+func (sa *stackAdapter) Size() int {
+	return sa.dq.Size()
+}
+
+func (sa *stackAdapter) Empty() bool {
+	return sa.dq.Empty()
+}
+
+func (sa *stackAdapter) Search(elem interface{}) int {
+	return sa.dq.SearchBack(elem)
+}
+
+func (sa *stackAdapter) Display() {
+	sa.dq.Display()
+}
+
+func (sa *stackAdapter) ToSlice() []interface{} {
+	return sa.dq.ToSlice()
+}
+
+// queueAdapter a deque seen as a queue
+type queueAdapter struct {
+	dq *dequeStruct
+}
+
+func (qa *queueAdapter) Push(elem ...interface{}) error {
+	return qa.dq.PushBack(elem...)
+}
+
+func (qa *queueAdapter) Pop() error {
+	return qa.dq.PopFront()
+}
+
+func (qa *queueAdapter) Pops(popCount int) error {
+	return qa.dq.PopFronts(popCount)
+}
+
+func (qa *queueAdapter) RemoveAll() {
+	qa.dq.RemoveAll()
+}
+
+func (qa *queueAdapter) Clear() {
+	qa.dq.Clear()
+}
+
+func (qa *queueAdapter) Front() (interface{}, error) {
+	return qa.dq.Front()
+}
+
+func (qa *queueAdapter) Fronts(frontCount int) ([]interface{}, error) {
+	return qa.dq.Fronts(frontCount)
+}
+
+func (qa *queueAdapter) FrontAndPop() (interface{}, error) {
+	return qa.dq.FrontAndPop()
+}
+
+func (qa *queueAdapter) FrontsAndPops(count int) ([]interface{}, error) {
+	return qa.dq.FrontsAndPops(count)
+}
+
+func (qa *queueAdapter) Size() int {
+	return qa.dq.Size()
+}
+
+func (qa *queueAdapter) Empty() bool {
+	return qa.dq.Empty()
+}
+
+func (qa *queueAdapter) Search(elem interface{}) int {
+	return qa.dq.Search(elem)
+}
+
+func (qa *queueAdapter) Display() {
+	qa.dq.Display()
+}
+
+func (qa *queueAdapter) ToSlice() []interface{} {
+	return qa.dq.ToSlice()
+}
@@ -0,0 +1,347 @@
+package Deque
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+	"github.com/FahimSifnatul/goDataStructures/internal/Ring"
+)
+
+// Deque a global function which creates, initializes and returns a double-ended queue instance
+// optional Option values (e.g. WithEqual) can be passed to configure the deque
+func Deque(opts ...Option) *dequeStruct {
+	dq := &dequeStruct{
+		deque: Ring.New[interface{}](),
+	}
+	for _, opt := range opts {
+		opt(dq)
+	}
+	return dq
+}
+
+// Option configures a deque created by Deque()
+type Option func(*dequeStruct)
+
+// WithEqual lets the deque accept elements of any data kind, including the unsupported ones
+// e.g. structs having slice fields, pointers or maps
+// equal is used by Search and SearchBack to compare two elements instead of ==
+func WithEqual(equal func(a, b interface{}) bool) Option {
+	return func(dq *dequeStruct) {
+		dq.equal = equal
+	}
+}
+
+// dequeStruct where deque data are stored
+// the data are kept in a circular buffer, so pushing and popping at both ends are amortized O(1)
+type dequeStruct struct {
+	deque         *Ring.Ring[interface{}]
+	dequeDataKind reflect.Kind
+	equal         func(a, b interface{}) bool
+}
+
+type dequeMethods interface {
+	// global methods
+
+	// PushFront adds one or more elements to the front of an existing deque one by one,
+	// so the last parametric element becomes the Front()
+	// returns error if data types mismatched and also doesn't push any value to the deque
+	PushFront(elem ...interface{}) error
+
+	// PushBack adds one or more elements to the back of an existing deque one by one,
+	// so the last parametric element becomes the Back()
+	// returns error if data types mismatched and also doesn't push any value to the deque
+	PushBack(elem ...interface{}) error
+
+	// PopFront removes the front element from the deque
+	PopFront() error
+
+	// PopBack removes the back element from the deque
+	PopBack() error
+
+	// PopFronts removes popCount elements from the front of the deque
+	// returns error if the popCount number is negative or bigger than the size of the deque
+	PopFronts(popCount int) error
+
+	// PopBacks removes popCount elements from the back of the deque
+	// returns error if the popCount number is negative or bigger than the size of the deque
+	PopBacks(popCount int) error
+
+	// RemoveAll it removes all elements from the caller deque
+	// but doesn't remove the data type
+	RemoveAll()
+
+	// Clear it removes all elements from the caller deque
+	// and also removes the data type
+	Clear()
+
+	// Front returns the front element and error (if deque is empty)
+	Front() (interface{}, error)
+
+	// Back returns the back element and error (if deque is empty)
+	Back() (interface{}, error)
+
+	// Fronts returns the front elements equal to frontCount (stored in a slice)
+	// ordered from front to back and error (if any)
+	Fronts(frontCount int) ([]interface{}, error)
+
+	// Backs returns the back elements equal to backCount (stored in a slice)
+	// ordered from front to back and error (if any)
+	Backs(backCount int) ([]interface{}, error)
+
+	// FrontAndPop returns the front element and also pops it from the deque
+	// also returns error (if any)
+	FrontAndPop() (interface{}, error)
+
+	// BackAndPop returns the back element and also pops it from the deque
+	// also returns error (if any)
+	BackAndPop() (interface{}, error)
+
+	// FrontsAndPops returns the front elements equal to count (ordered from front to back)
+	// and also pops those elements from the deque
+	// and error (if any)
+	FrontsAndPops(count int) ([]interface{}, error)
+
+	// BacksAndPops returns the back elements equal to count (ordered from front to back)
+	// and also pops those elements from the deque
+	// and error (if any)
+	BacksAndPops(count int) ([]interface{}, error)
+
+	// Size returns the size of an existing deque
+	Size() int
+
+	// Empty checks whether the deque is empty or not
+	// returns true if empty else false
+	Empty() bool
+
+	// Search finds the parametric element in the deque
+	// if the element is found then returns the position from the Front() else -1 (not found)
+	// N.B. Front() is taken as position 1
+	Search(elem interface{}) int
+
+	// SearchBack finds the parametric element in the deque
+	// if the element is found then returns the position from the Back() else -1 (not found)
+	// N.B. Back() is taken as position 1
+	SearchBack(elem interface{}) int
+
+	// Display prints the deque value as slice on console screen
+	// the left most data is the front and the right most data is the back
+	Display()
+
+	// ToSlice returns the deque as slice ordered from front to back
+	ToSlice() []interface{}
+
+	// private methods (for internal use only)
+
+	// checkDataKind checks the data kind of the elements of a deque
+	// a deque must contain elements having same data kind
+	checkDataKind(value interface{}) error
+
+	// isEqual compares two elements using the equal function (if provided) else ==
+	isEqual(a, b interface{}) bool
+}
+
+func (dq *dequeStruct) PushFront(elem ...interface{}) error {
+	for _, e := range elem {
+		if err := dq.checkDataKind(e); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range elem {
+		dq.deque.PushFront(e)
+	}
+	return nil
+}
+
+func (dq *dequeStruct) PushBack(elem ...interface{}) error {
+	for _, e := range elem {
+		if err := dq.checkDataKind(e); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range elem {
+		dq.deque.PushBack(e)
+	}
+	return nil
+}
+
+func (dq *dequeStruct) PopFront() error {
+	if dq.Empty() {
+		return ErrEmpty
+	}
+
+	dq.deque.PopFront()
+	return nil
+}
+
+func (dq *dequeStruct) PopBack() error {
+	if dq.Empty() {
+		return ErrEmpty
+	}
+
+	dq.deque.PopBack()
+	return nil
+}
+
+func (dq *dequeStruct) PopFronts(popCount int) error {
+	if err := checkCount("pop", popCount, dq.Size()); err != nil {
+		return err
+	}
+
+	dq.deque.PopFronts(popCount)
+	return nil
+}
+
+func (dq *dequeStruct) PopBacks(popCount int) error {
+	if err := checkCount("pop", popCount, dq.Size()); err != nil {
+		return err
+	}
+
+	dq.deque.PopBacks(popCount)
+	return nil
+}
+
+func (dq *dequeStruct) RemoveAll() {
+	dq.deque.Clear()
+}
+
+func (dq *dequeStruct) Clear() {
+	dq.RemoveAll()
+	dq.dequeDataKind = reflect.Invalid
+}
+
+func (dq *dequeStruct) Front() (interface{}, error) {
+	if dq.Empty() {
+		return nil, ErrEmpty
+	}
+
+	return dq.deque.Front(), nil
+}
+
+func (dq *dequeStruct) Back() (interface{}, error) {
+	if dq.Empty() {
+		return nil, ErrEmpty
+	}
+
+	return dq.deque.Back(), nil
+}
+
+func (dq *dequeStruct) Fronts(frontCount int) ([]interface{}, error) {
+	if err := checkCount("front", frontCount, dq.Size()); err != nil {
+		return nil, err
+	}
+
+	return dq.deque.Slice(0, frontCount), nil
+}
+
+func (dq *dequeStruct) Backs(backCount int) ([]interface{}, error) {
+	dequeSize := dq.Size()
+	if err := checkCount("back", backCount, dequeSize); err != nil {
+		return nil, err
+	}
+
+	return dq.deque.Slice(dequeSize-backCount, backCount), nil
+}
+
+func (dq *dequeStruct) FrontAndPop() (interface{}, error) {
+	elem, err := dq.Front()
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.PopFront(); err != nil {
+		return nil, err
+	}
+	return elem, nil
+}
+
+func (dq *dequeStruct) BackAndPop() (interface{}, error) {
+	elem, err := dq.Back()
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.PopBack(); err != nil {
+		return nil, err
+	}
+	return elem, nil
+}
+
+func (dq *dequeStruct) FrontsAndPops(count int) ([]interface{}, error) {
+	elemSlice, err := dq.Fronts(count)
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.PopFronts(count); err != nil {
+		return nil, err
+	}
+	return elemSlice, nil
+}
+
+func (dq *dequeStruct) BacksAndPops(count int) ([]interface{}, error) {
+	elemSlice, err := dq.Backs(count)
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.PopBacks(count); err != nil {
+		return nil, err
+	}
+	return elemSlice, nil
+}
+
+func (dq *dequeStruct) Size() int {
+	return dq.deque.Len()
+}
+
+func (dq *dequeStruct) Empty() bool {
+	return dq.Size() == 0
+}
+
+func (dq *dequeStruct) Search(elem interface{}) int {
+	for i := 0; i < dq.Size(); i++ {
+		if dq.isEqual(dq.deque.At(i), elem) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (dq *dequeStruct) SearchBack(elem interface{}) int {
+	dequeSize := dq.Size()
+	for i := dequeSize - 1; i >= 0; i-- {
+		if dq.isEqual(dq.deque.At(i), elem) {
+			return dequeSize - i
+		}
+	}
+	return -1
+}
+
+func (dq *dequeStruct) Display() {
+	fmt.Println(dq.ToSlice())
+}
+
+func (dq *dequeStruct) ToSlice() []interface{} {
+	return dq.deque.Slice(0, dq.Size())
+}
+
+func (dq *dequeStruct) checkDataKind(val interface{}) error {
+	valKind := reflect.TypeOf(val).Kind()
+
+	if dq.dequeDataKind != reflect.Invalid && dq.dequeDataKind != valKind {
+		return &KindMismatchError{Expected: dq.dequeDataKind, Got: valKind}
+	}
+
+	if dq.equal == nil && !Codec.IsSupportedType(reflect.TypeOf(val)) {
+		return &UnsupportedKindError{Kind: valKind}
+	}
+
+	dq.dequeDataKind = valKind
+	return nil
+}
+
+func (dq *dequeStruct) isEqual(a, b interface{}) bool {
+	if dq.equal != nil {
+		return dq.equal(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,74 @@
+package Deque
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDequeTypedErrors(t *testing.T) {
+	dq := Deque()
+	if _, err := dq.Front(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Front() = %v, want ErrEmpty", err)
+	}
+	if err := dq.PopBack(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("PopBack() = %v, want ErrEmpty", err)
+	}
+
+	_ = dq.PushBack(1, 2, 3)
+	if err := dq.PopFronts(4); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("PopFronts(4) = %v, want ErrCountExceedsSize", err)
+	}
+	if _, err := dq.Backs(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Backs(-1) = %v, want ErrNegativeCount", err)
+	}
+
+	var mismatch *KindMismatchError
+	if err := dq.PushFront("a"); !errors.As(err, &mismatch) || mismatch.Expected != reflect.Int || mismatch.Got != reflect.String {
+		t.Fatalf("PushFront(\"a\") = %v, want a *KindMismatchError from int to string", err)
+	}
+	var unsupported *UnsupportedKindError
+	if err := Deque().PushBack([]int{1}); !errors.As(err, &unsupported) || unsupported.Kind != reflect.Slice {
+		t.Fatalf("PushBack([]int{1}) = %v, want a *UnsupportedKindError of slice", err)
+	}
+	if got := dq.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("rejected calls changed the deque to %v", got)
+	}
+}
+
+func TestDequeBothEnds(t *testing.T) {
+	dq := Deque()
+	want := make([]interface{}, 0)
+	for i := 0; i < 100; i++ {
+		if i%3 == 0 {
+			_ = dq.PushFront(i)
+			want = append([]interface{}{i}, want...)
+		} else {
+			_ = dq.PushBack(i)
+			want = append(want, i)
+		}
+		if i%4 == 3 {
+			front, _ := dq.FrontAndPop()
+			back, _ := dq.BackAndPop()
+			if front != want[0] || back != want[len(want)-1] {
+				t.Fatalf("step %d: popped %v and %v, want %v and %v", i, front, back, want[0], want[len(want)-1])
+			}
+			want = want[1 : len(want)-1]
+		}
+	}
+	if got := dq.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("deque is %v, want %v", got, want)
+	}
+
+	backs, _ := dq.BacksAndPops(3)
+	if !reflect.DeepEqual(backs, want[len(want)-3:]) {
+		t.Fatalf("BacksAndPops(3) = %v, want %v", backs, want[len(want)-3:])
+	}
+	want = want[:len(want)-3]
+	if pos := dq.SearchBack(want[len(want)-1]); pos != 1 {
+		t.Fatalf("SearchBack(back) = %d, want 1", pos)
+	}
+	if pos := dq.Search(want[1]); pos != 2 {
+		t.Fatalf("Search(second) = %d, want 2", pos)
+	}
+}
@@ -0,0 +1,81 @@
+package Deque
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrEmpty is returned when an element is requested from or popped from an empty deque
+	ErrEmpty = errors.New("invalid operation as deque is empty")
+
+	// ErrCountExceedsSize is matched (with errors.Is) by the errors returned when
+	// the requested element count is bigger than the size of the deque
+	ErrCountExceedsSize = errors.New("invalid operation as count is greater than the deque size")
+
+	// ErrNegativeCount is matched (with errors.Is) by the errors returned when the requested element count is negative
+	ErrNegativeCount = errors.New("invalid operation as count is negative")
+)
+
+// KindMismatchError is returned when an element's data kind differs from the data kind of the deque
+type KindMismatchError struct {
+	Expected reflect.Kind
+	Got      reflect.Kind
+}
+
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("invalid value type: expected %v, got %v", e.Expected, e.Got)
+}
+
+// UnsupportedKindError is returned when an element's data kind can't be stored in a deque
+// see Codec.UnsupportedKinds and the options which lift this restriction
+type UnsupportedKindError struct {
+	Kind reflect.Kind
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return fmt.Sprintf("%v is not supported type for deque", e.Kind)
+}
+
+// countExceedsSizeError describes which count (pop, front, back, ...) is bigger than the deque size
+// it matches ErrCountExceedsSize
+type countExceedsSizeError struct {
+	op    string
+	count int
+	size  int
+}
+
+func (e *countExceedsSizeError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is greater than the deque size(%d)", e.op, e.count, e.size)
+}
+
+func (e *countExceedsSizeError) Is(target error) bool {
+	return target == ErrCountExceedsSize
+}
+
+// negativeCountError describes which count (pop, front, back, ...) is negative
+// it matches ErrNegativeCount
+type negativeCountError struct {
+	op    string
+	count int
+}
+
+func (e *negativeCountError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is negative", e.op, e.count)
+}
+
+func (e *negativeCountError) Is(target error) bool {
+	return target == ErrNegativeCount
+}
+
+// checkCount returns error if the count of the op is negative or bigger than the size of the deque
+func checkCount(op string, count, size int) error {
+	if count < 0 {
+		return &negativeCountError{op: op, count: count}
+	}
+	if count > size {
+		return &countExceedsSizeError{op: op, count: count, size: size}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package Expression
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownVariable is matched (with errors.Is) by the errors returned by Eval
+	// when a variable of the expression is missing from the variable map
+	ErrUnknownVariable = errors.New("invalid operation as variable is unknown")
+
+	// ErrDivisionByZero is matched (with errors.Is) by the errors returned by Eval
+	// when the right operand of / or % is zero
+	ErrDivisionByZero = errors.New("invalid operation as divisor is zero")
+
+	// ErrInvalidName is returned when an operator symbol or a function name can't be registered
+	ErrInvalidName = errors.New("invalid operator symbol or function name")
+)
+
+// SyntaxError is returned when an expression can't be parsed
+// Offset is the byte offset of the offending token in the expression
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// EvalError is returned when an operator, a function or a variable of a parsed expression fails to evaluate
+// Offset is the byte offset of Token in the expression and Err the cause which errors.Is and errors.As see
+type EvalError struct {
+	Offset int
+	Token  string
+	Err    error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("cannot evaluate %q at offset %d: %v", e.Token, e.Offset, e.Err)
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,436 @@
+package Expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/FahimSifnatul/goDataStructures/Stack"
+)
+
+// Associativity decides how the operators of the same precedence are grouped
+type Associativity int
+
+const (
+	// LeftAssociative groups from the left i.e. a - b - c is (a - b) - c
+	LeftAssociative Associativity = iota
+
+	// RightAssociative groups from the right i.e. a ^ b ^ c is a ^ (b ^ c)
+	RightAssociative
+)
+
+// UnaryPrecedence is the precedence of the unary minus
+// the built-in operators have the precedences + and - 10, * / and % 20, ^ 40,
+// so -2 * 3 is (-2) * 3 and -2 ^ 2 is -(2 ^ 2)
+const UnaryPrecedence = 30
+
+// operator a registered binary operator
+type operator struct {
+	symbol     string
+	precedence int
+	assoc      Associativity
+	apply      func(a, b float64) (float64, error)
+}
+
+// function a registered function, arity < 0 accepts any number of arguments
+type function struct {
+	name  string
+	arity int
+	apply func(args ...float64) (float64, error)
+}
+
+// New a global function which creates, initializes and returns a parser instance
+// having the built-in operators + - * / % ^ and the functions abs, ceil, floor, round, sqrt, exp, log, min and max
+func New() *parser {
+	p := &parser{
+		operators: make(map[string]*operator),
+		functions: make(map[string]*function),
+	}
+
+	arithmetic := []struct {
+		symbol     string
+		precedence int
+		assoc      Associativity
+		apply      func(a, b float64) (float64, error)
+	}{
+		{"+", 10, LeftAssociative, func(a, b float64) (float64, error) { return a + b, nil }},
+		{"-", 10, LeftAssociative, func(a, b float64) (float64, error) { return a - b, nil }},
+		{"*", 20, LeftAssociative, func(a, b float64) (float64, error) { return a * b, nil }},
+		{"/", 20, LeftAssociative, func(a, b float64) (float64, error) {
+			if b == 0 {
+				return 0, ErrDivisionByZero
+			}
+			return a / b, nil
+		}},
+		{"%", 20, LeftAssociative, func(a, b float64) (float64, error) {
+			if b == 0 {
+				return 0, ErrDivisionByZero
+			}
+			return math.Mod(a, b), nil
+		}},
+		{"^", 40, RightAssociative, func(a, b float64) (float64, error) { return math.Pow(a, b), nil }},
+	}
+	for _, op := range arithmetic {
+		_ = p.RegisterOperator(op.symbol, op.precedence, op.assoc, op.apply)
+	}
+
+	unary := map[string]func(float64) float64{
+		"abs":   math.Abs,
+		"ceil":  math.Ceil,
+		"floor": math.Floor,
+		"round": math.Round,
+		"sqrt":  math.Sqrt,
+		"exp":   math.Exp,
+		"log":   math.Log,
+	}
+	for name, f := range unary {
+		_ = p.RegisterFunction(name, 1, func(args ...float64) (float64, error) { return f(args[0]), nil })
+	}
+	_ = p.RegisterFunction("min", -1, extremeOf(math.Min))
+	_ = p.RegisterFunction("max", -1, extremeOf(math.Max))
+	return p
+}
+
+// parser where the registered operators and functions are stored
+// longestSymbol is the length of the longest operator symbol, used by the tokenizer
+type parser struct {
+	operators     map[string]*operator
+	functions     map[string]*function
+	longestSymbol int
+}
+
+// parserMethods stores interface declaration of all parser methods
+type parserMethods interface {
+	// RegisterOperator adds a binary operator or replaces the operator having the same symbol
+	// the symbol can't contain letters, digits, white space, dots, parentheses or commas
+	// returns error if the symbol is invalid, see UnaryPrecedence for the precedences of the built-in operators
+	// expressions parsed before keep the operators they were parsed with
+	RegisterOperator(symbol string, precedence int, assoc Associativity, apply func(a, b float64) (float64, error)) error
+
+	// RegisterFunction adds a function or replaces the function having the same name
+	// arity is the number of arguments, arity < 0 accepts any number of arguments
+	// returns error if the name isn't an identifier i.e. a letter or _ followed by letters, digits or _
+	// expressions parsed before keep the functions they were parsed with
+	RegisterFunction(name string, arity int, apply func(args ...float64) (float64, error)) error
+
+	// Parse converts the infix expression to reverse polish notation with the shunting-yard algorithm
+	// an identifier followed by ( is a function call, any other identifier is a variable
+	// returns *SyntaxError pointing to the offending token if the expression is malformed
+	Parse(expr string) (*expression, error)
+
+	// Eval parses and evaluates the expression against the variables
+	Eval(expr string, vars map[string]float64) (float64, error)
+
+	// private methods (for internal use only)
+
+	// tokenize splits the expression into tokens
+	tokenize(expr string) ([]token, error)
+
+	// matchOperator returns the operator having the longest symbol the text starts with or nil
+	matchOperator(text string) *operator
+}
+
+func (p *parser) RegisterOperator(symbol string, precedence int, assoc Associativity, apply func(a, b float64) (float64, error)) error {
+	if !isSymbol(symbol) || apply == nil {
+		return fmt.Errorf("%w: operator %q", ErrInvalidName, symbol)
+	}
+
+	p.operators[symbol] = &operator{symbol: symbol, precedence: precedence, assoc: assoc, apply: apply}
+	p.longestSymbol = max(p.longestSymbol, len(symbol))
+	return nil
+}
+
+func (p *parser) RegisterFunction(name string, arity int, apply func(args ...float64) (float64, error)) error {
+	if !isIdentifier(name) || apply == nil {
+		return fmt.Errorf("%w: function %q", ErrInvalidName, name)
+	}
+
+	p.functions[name] = &function{name: name, arity: arity, apply: apply}
+	return nil
+}
+
+func (p *parser) Parse(expr string) (*expression, error) {
+	tokens, err := p.tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, &SyntaxError{Offset: 0, Msg: "empty expression"}
+	}
+
+	// ops holds the pending operators, functions and left parentheses
+	// argCounts holds the argument count of every open function call
+	ops := Stack.New[token]()
+	argCounts := Stack.New[int]()
+	rpn := make([]token, 0, len(tokens))
+	expectOperand := true
+
+	// popUntilParen moves the operators above the innermost left parenthesis to the output
+	// returns false if there is no left parenthesis
+	popUntilParen := func() bool {
+		for !ops.Empty() {
+			top, _ := ops.Top()
+			if top.kind == tokenLeftParen {
+				return true
+			}
+			_ = ops.Pop()
+			rpn = append(rpn, top)
+		}
+		return false
+	}
+	// isCallParen reports whether the innermost left parenthesis (the Top()) opens a function call
+	isCallParen := func() bool {
+		tops, err := ops.Tops(2)
+		return err == nil && tops[0].kind == tokenFunction
+	}
+
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokenNumber:
+			if !expectOperand {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "unexpected number " + tok.text}
+			}
+			rpn = append(rpn, tok)
+			expectOperand = false
+
+		case tokenIdentifier:
+			if !expectOperand {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "unexpected identifier " + tok.text}
+			}
+			if i+1 < len(tokens) && tokens[i+1].kind == tokenLeftParen {
+				fn, found := p.functions[tok.text]
+				if !found {
+					return nil, &SyntaxError{Offset: tok.offset, Msg: "unknown function " + tok.text}
+				}
+				tok.kind, tok.fn = tokenFunction, fn
+				ops.Push(tok)
+				continue
+			}
+			tok.kind = tokenVariable
+			rpn = append(rpn, tok)
+			expectOperand = false
+
+		case tokenLeftParen:
+			if !expectOperand {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "unexpected ("}
+			}
+			ops.Push(tok)
+			if isCallParen() {
+				argCounts.Push(1)
+			}
+
+		case tokenComma:
+			if expectOperand {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "missing argument"}
+			}
+			if !popUntilParen() || !isCallParen() {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "unexpected , outside of a function call"}
+			}
+			argCount, _ := argCounts.TopAndPop()
+			argCounts.Push(argCount + 1)
+			expectOperand = true
+
+		case tokenRightParen:
+			emptyCall := false
+			if expectOperand {
+				// only a function call without arguments e.g. f() can be closed right after its (
+				emptyCall = i > 0 && tokens[i-1].kind == tokenLeftParen && isCallParen()
+				if !emptyCall {
+					return nil, &SyntaxError{Offset: tok.offset, Msg: "missing operand before )"}
+				}
+			}
+			if !popUntilParen() {
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "unmatched )"}
+			}
+			call := isCallParen()
+			_ = ops.Pop()
+			if call {
+				fn, _ := ops.TopAndPop()
+				fn.argc, _ = argCounts.TopAndPop()
+				if emptyCall {
+					fn.argc = 0
+				}
+				if fn.fn.arity >= 0 && fn.argc != fn.fn.arity {
+					msg := fmt.Sprintf("function %s expects %d arguments, got %d", fn.text, fn.fn.arity, fn.argc)
+					return nil, &SyntaxError{Offset: fn.offset, Msg: msg}
+				}
+				rpn = append(rpn, fn)
+			}
+			expectOperand = false
+
+		case tokenOperator:
+			if expectOperand {
+				switch tok.text {
+				case "-":
+					tok.kind = tokenUnary
+					ops.Push(tok)
+					continue
+				case "+":
+					// unary plus changes nothing
+					continue
+				}
+				return nil, &SyntaxError{Offset: tok.offset, Msg: "missing operand before " + tok.text}
+			}
+			for !ops.Empty() {
+				top, _ := ops.Top()
+				precedence, isOperator := top.precedence()
+				if !isOperator || precedence < tok.op.precedence ||
+					(precedence == tok.op.precedence && tok.op.assoc == RightAssociative) {
+					break
+				}
+				_ = ops.Pop()
+				rpn = append(rpn, top)
+			}
+			ops.Push(tok)
+			expectOperand = true
+		}
+	}
+
+	if expectOperand {
+		return nil, &SyntaxError{Offset: len(expr), Msg: "unexpected end of expression"}
+	}
+	for !ops.Empty() {
+		top, _ := ops.TopAndPop()
+		if top.kind == tokenLeftParen {
+			return nil, &SyntaxError{Offset: top.offset, Msg: "unmatched ("}
+		}
+		rpn = append(rpn, top)
+	}
+	return &expression{source: expr, rpn: rpn}, nil
+}
+
+func (p *parser) Eval(expr string, vars map[string]float64) (float64, error) {
+	e, err := p.Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval(vars)
+}
+
+// Eval parses and evaluates the expression with the built-in operators and functions, see New
+func Eval(expr string, vars map[string]float64) (float64, error) {
+	return New().Eval(expr, vars)
+}
+
+// expression a parsed expression stored in reverse polish notation, it can be evaluated many times
+type expression struct {
+	source string
+	rpn    []token
+}
+
+// expressionMethods stores interface declaration of all expression methods
+type expressionMethods interface {
+	// Eval evaluates the expression against the variables using a stack of operands
+	// returns *EvalError if a variable is missing or an operator or a function fails
+	Eval(vars map[string]float64) (float64, error)
+
+	// RPN returns the tokens of the expression in reverse polish notation
+	// the unary minus is written as neg and a function call as name/argument count e.g. max/3
+	RPN() []string
+
+	// Variables returns the names of the variables of the expression in order of appearance, without duplicates
+	Variables() []string
+
+	// String returns the source of the expression
+	String() string
+}
+
+func (e *expression) Eval(vars map[string]float64) (float64, error) {
+	operands := Stack.New[float64]()
+	for _, tok := range e.rpn {
+		switch tok.kind {
+		case tokenNumber:
+			operands.Push(tok.value)
+		case tokenVariable:
+			value, found := vars[tok.text]
+			if !found {
+				return 0, &EvalError{Offset: tok.offset, Token: tok.text, Err: ErrUnknownVariable}
+			}
+			operands.Push(value)
+		case tokenUnary:
+			value, _ := operands.TopAndPop()
+			operands.Push(-value)
+		case tokenOperator:
+			args, _ := operands.TopsAndPops(2)
+			result, err := tok.op.apply(args[0], args[1])
+			if err != nil {
+				return 0, &EvalError{Offset: tok.offset, Token: tok.text, Err: err}
+			}
+			operands.Push(result)
+		case tokenFunction:
+			args, _ := operands.TopsAndPops(tok.argc)
+			result, err := tok.fn.apply(args...)
+			if err != nil {
+				return 0, &EvalError{Offset: tok.offset, Token: tok.text, Err: err}
+			}
+			operands.Push(result)
+		}
+	}
+
+	result, err := operands.Top()
+	if err != nil || operands.Size() != 1 {
+		// Parse only builds balanced expressions
+		return 0, errors.New("malformed expression")
+	}
+	return result, nil
+}
+
+func (e *expression) RPN() []string {
+	rpn := make([]string, len(e.rpn))
+	for i, tok := range e.rpn {
+		switch tok.kind {
+		case tokenUnary:
+			rpn[i] = "neg"
+		case tokenFunction:
+			rpn[i] = tok.text + "/" + strconv.Itoa(tok.argc)
+		default:
+			rpn[i] = tok.text
+		}
+	}
+	return rpn
+}
+
+func (e *expression) Variables() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, tok := range e.rpn {
+		if tok.kind == tokenVariable && !seen[tok.text] {
+			seen[tok.text] = true
+			names = append(names, tok.text)
+		}
+	}
+	return names
+}
+
+func (e *expression) String() string {
+	return e.source
+}
+
+// precedence returns the precedence of an operator or a unary minus token
+// returns false for any other token i.e. left parentheses and functions which stop the popping
+func (tok token) precedence() (int, bool) {
+	switch tok.kind {
+	case tokenOperator:
+		return tok.op.precedence, true
+	case tokenUnary:
+		return UnaryPrecedence, true
+	default:
+		return 0, false
+	}
+}
+
+// extremeOf returns a function which folds its arguments with pick e.g. math.Max
+// returns error if there is no argument
+func extremeOf(pick func(a, b float64) float64) func(args ...float64) (float64, error) {
+	return func(args ...float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, errors.New("at least one argument is expected")
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = pick(result, arg)
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,237 @@
+package Expression
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"x": 3, "y": 4, "_tmp2": 0.5}
+	tests := []struct {
+		name string
+		expr string
+		want float64
+		rpn  []string
+	}{
+		// precedence
+		{"mul before add", "1 + 2 * 3", 7, []string{"1", "2", "3", "*", "+"}},
+		{"div before sub", "10 - 6 / 2", 7, []string{"10", "6", "2", "/", "-"}},
+		{"pow before mul", "2 * 3 ^ 2", 18, []string{"2", "3", "2", "^", "*"}},
+		{"mod like mul", "7 + 10 % 4", 9, nil},
+		{"parentheses", "(1 + 2) * 3", 9, []string{"1", "2", "+", "3", "*"}},
+		{"nested parentheses", "((2))", 2, []string{"2"}},
+
+		// associativity
+		{"sub is left", "10 - 4 - 3", 3, []string{"10", "4", "-", "3", "-"}},
+		{"div is left", "64 / 4 / 2", 8, nil},
+		{"pow is right", "2 ^ 3 ^ 2", 512, []string{"2", "3", "2", "^", "^"}},
+
+		// unary minus and plus
+		{"unary minus", "-3", -3, []string{"3", "neg"}},
+		{"unary before mul", "-2 * 3", -6, []string{"2", "neg", "3", "*"}},
+		{"pow before unary", "-2 ^ 2", -4, []string{"2", "2", "^", "neg"}},
+		{"unary in exponent", "2 ^ -1", 0.5, []string{"2", "1", "neg", "^"}},
+		{"unary after operator", "3 * -x", -9, []string{"3", "x", "neg", "*"}},
+		{"double unary", "--3", 3, []string{"3", "neg", "neg"}},
+		{"unary before parenthesis", "-(1 + 2) * 2", -6, nil},
+		{"unary plus", "+3 - +2", 1, []string{"3", "2", "-"}},
+
+		// numbers and variables
+		{"fraction", ".5 + 1.25", 1.75, nil},
+		{"exponent", "1.5e2 + 2E-1", 150.2, nil},
+		{"variables", "x * y + _tmp2", 12.5, []string{"x", "y", "*", "_tmp2", "+"}},
+
+		// function calls
+		{"unary function", "sqrt(x * 3)", 3, []string{"x", "3", "*", "sqrt/1"}},
+		{"variadic function", "max(1, y, x)", 4, []string{"1", "y", "x", "max/3"}},
+		{"single argument", "min(x)", 3, []string{"x", "min/1"}},
+		{"nested calls", "max(abs(-5), min(2, 3) * 2)", 5, nil},
+		{"call in expression", "2 * floor(x / 2) + 1", 3, nil},
+		{"call arguments with operators", "max(1 + 2, 2 ^ 2)", 4, []string{"1", "2", "+", "2", "2", "^", "max/2"}},
+		{"parenthesized argument", "round((x + y) / 2)", 4, nil},
+		{"negated call", "-abs(x)", -3, []string{"x", "abs/1", "neg"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New().Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v", tt.expr, err)
+			}
+			if tt.rpn != nil && !slices.Equal(e.RPN(), tt.rpn) {
+				t.Fatalf("RPN() = %v, want %v", e.RPN(), tt.rpn)
+			}
+			got, err := e.Eval(vars)
+			if err != nil {
+				t.Fatalf("Eval() = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmptyArgumentList(t *testing.T) {
+	p := New()
+	if err := p.RegisterFunction("pi", 0, func(args ...float64) (float64, error) { return math.Pi, nil }); err != nil {
+		t.Fatal(err)
+	}
+	e, err := p.Parse("2 * pi()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2", "pi/0", "*"}; !slices.Equal(e.RPN(), want) {
+		t.Fatalf("RPN() = %v, want %v", e.RPN(), want)
+	}
+	if got, _ := e.Eval(nil); got != 2*math.Pi {
+		t.Fatalf("Eval() = %v, want %v", got, 2*math.Pi)
+	}
+
+	// a variadic function is called without arguments, min fails when evaluated
+	e, err = p.Parse("min( )")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evalErr *EvalError
+	if _, err := e.Eval(nil); !errors.As(err, &evalErr) || evalErr.Token != "min" || evalErr.Offset != 0 {
+		t.Fatalf("Eval() = %v, want an *EvalError of min at offset 0", err)
+	}
+}
+
+func TestRegisterOperator(t *testing.T) {
+	p := New()
+	pow := func(a, b float64) (float64, error) { return math.Pow(a, b), nil }
+	if err := p.RegisterOperator("**", 40, RightAssociative, pow); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RegisterOperator("<>", 5, LeftAssociative, func(a, b float64) (float64, error) { return math.Abs(a - b), nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr string
+		want float64
+		rpn  []string
+	}{
+		// the longest symbol wins, ** isn't read as two *
+		{"2**3", 8, []string{"2", "3", "**"}},
+		{"2 ** 3 ** 2", 512, []string{"2", "3", "2", "**", "**"}},
+		{"2 * 3 ** 2", 18, []string{"2", "3", "2", "**", "*"}},
+		{"-2 ** 2", -4, []string{"2", "2", "**", "neg"}},
+		// <> binds looser than everything built in
+		{"1 + 2 <> 10 - 1", 6, []string{"1", "2", "+", "10", "1", "-", "<>"}},
+		{"1 <> 2 <> 4", 3, []string{"1", "2", "<>", "4", "<>"}},
+	}
+	for _, tt := range tests {
+		e, err := p.Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %v", tt.expr, err)
+		}
+		if !slices.Equal(e.RPN(), tt.rpn) {
+			t.Fatalf("%q: RPN() = %v, want %v", tt.expr, e.RPN(), tt.rpn)
+		}
+		if got, _ := e.Eval(nil); got != tt.want {
+			t.Fatalf("%q: Eval() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	// replacing an operator doesn't change the expressions parsed before
+	before, _ := p.Parse("7 - 2")
+	if err := p.RegisterOperator("-", 10, LeftAssociative, func(a, b float64) (float64, error) { return b - a, nil }); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := p.Parse("7 - 2")
+	if got, _ := before.Eval(nil); got != 5 {
+		t.Fatalf("expression parsed before the replacement = %v, want 5", got)
+	}
+	if got, _ := after.Eval(nil); got != -5 {
+		t.Fatalf("expression parsed after the replacement = %v, want -5", got)
+	}
+
+	for _, symbol := range []string{"", "a", "+1", "(", ",", ".", "+ ", "\xff"} {
+		if err := p.RegisterOperator(symbol, 1, LeftAssociative, pow); !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("RegisterOperator(%q) = %v, want ErrInvalidName", symbol, err)
+		}
+	}
+	if err := p.RegisterOperator("@", 1, LeftAssociative, nil); !errors.Is(err, ErrInvalidName) {
+		t.Fatalf("RegisterOperator with a nil apply = %v, want ErrInvalidName", err)
+	}
+	for _, name := range []string{"", "1f", "f-g", "f g"} {
+		if err := p.RegisterFunction(name, 1, func(args ...float64) (float64, error) { return 0, nil }); !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("RegisterFunction(%q) = %v, want ErrInvalidName", name, err)
+		}
+	}
+}
+
+func TestSyntaxErrorOffsets(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+	}{
+		{"", 0},
+		{"   ", 0},
+		{"1 +", 3},
+		{"1 + * 2", 4},
+		{"* 2", 0},
+		{"1 2", 2},
+		{"x y", 2},
+		{"2 (3)", 2},
+		{"(1 + 2", 0},
+		{"1 + (2 * (3)", 4},
+		{"1 + 2)", 5},
+		{"()", 1},
+		{"1 $ 2", 2},
+		{"1.5.2", 3},
+		{"foo(1)", 0},
+		{"1 + sqrt(1, 2)", 4},
+		{"max(1, )", 7},
+		{"max(, 1)", 4},
+		{"1, 2", 1},
+		{"(1, 2)", 2},
+		{"max(1 2)", 6},
+	}
+	for _, tt := range tests {
+		_, err := New().Parse(tt.expr)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("Parse(%q) = %v, want a *SyntaxError", tt.expr, err)
+		}
+		if syntaxErr.Offset != tt.offset {
+			t.Fatalf("Parse(%q) = %v, want offset %d", tt.expr, err, tt.offset)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		vars   map[string]float64
+		err    error
+		token  string
+		offset int
+	}{
+		{"1 / (x - x)", map[string]float64{"x": 1}, ErrDivisionByZero, "/", 2},
+		{"5 % 0", nil, ErrDivisionByZero, "%", 2},
+		{"2 * (y + 1)", map[string]float64{"x": 1}, ErrUnknownVariable, "y", 5},
+	}
+	for _, tt := range tests {
+		_, err := Eval(tt.expr, tt.vars)
+		var evalErr *EvalError
+		if !errors.Is(err, tt.err) || !errors.As(err, &evalErr) {
+			t.Fatalf("Eval(%q) = %v, want an *EvalError matching %v", tt.expr, err, tt.err)
+		}
+		if evalErr.Token != tt.token || evalErr.Offset != tt.offset {
+			t.Fatalf("Eval(%q) = %v, want token %q at offset %d", tt.expr, err, tt.token, tt.offset)
+		}
+	}
+
+	e, _ := New().Parse("a + b * a")
+	if want := []string{"a", "b"}; !slices.Equal(e.Variables(), want) {
+		t.Fatalf("Variables() = %v, want %v", e.Variables(), want)
+	}
+	if e.String() != "a + b * a" {
+		t.Fatalf("String() = %q", e.String())
+	}
+}
@@ -0,0 +1,164 @@
+package Expression
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind the kind of a token, the parser turns identifiers into variables or functions
+// and the operators found where an operand is expected into unary operators
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdentifier
+	tokenVariable
+	tokenFunction
+	tokenOperator
+	tokenUnary
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+// token a piece of an expression, offset is its byte offset in the expression
+// op and fn are resolved when the token is an operator or a function, argc is the argument count of a function call
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+	value  float64
+	op     *operator
+	fn     *function
+	argc   int
+}
+
+// tokenize splits the expression into tokens
+// operators are matched by the longest registered symbol, so e.g. ** wins over *
+func (p *parser) tokenize(expr string) ([]token, error) {
+	tokens := make([]token, 0)
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case isDigit(expr[i]) || (expr[i] == '.' && i+1 < len(expr) && isDigit(expr[i+1])):
+			end := scanNumber(expr, i)
+			value, err := strconv.ParseFloat(expr[i:end], 64)
+			if err != nil {
+				return nil, &SyntaxError{Offset: i, Msg: "invalid number " + strconv.Quote(expr[i:end])}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:end], offset: i, value: value})
+			i = end
+		case isIdentifierStart(r):
+			end := scanIdentifier(expr, i)
+			tokens = append(tokens, token{kind: tokenIdentifier, text: expr[i:end], offset: i})
+			i = end
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", offset: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", offset: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", offset: i})
+			i++
+		default:
+			op := p.matchOperator(expr[i:])
+			if op == nil {
+				return nil, &SyntaxError{Offset: i, Msg: "unexpected character " + strconv.QuoteRune(r)}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op.symbol, offset: i, op: op})
+			i += len(op.symbol)
+		}
+	}
+	return tokens, nil
+}
+
+// matchOperator returns the operator having the longest symbol the text starts with or nil
+func (p *parser) matchOperator(text string) *operator {
+	for length := min(p.longestSymbol, len(text)); length > 0; length-- {
+		if op, found := p.operators[text[:length]]; found {
+			return op
+		}
+	}
+	return nil
+}
+
+// scanNumber returns the end of the number starting at start
+// i.e. digits with an optional fraction and an optional exponent like 1.5e-3
+func scanNumber(expr string, start int) int {
+	i := start
+	for i < len(expr) && isDigit(expr[i]) {
+		i++
+	}
+	if i < len(expr) && expr[i] == '.' {
+		i++
+		for i < len(expr) && isDigit(expr[i]) {
+			i++
+		}
+	}
+	if i < len(expr) && (expr[i] == 'e' || expr[i] == 'E') {
+		j := i + 1
+		if j < len(expr) && (expr[j] == '+' || expr[j] == '-') {
+			j++
+		}
+		if j < len(expr) && isDigit(expr[j]) {
+			i = j
+			for i < len(expr) && isDigit(expr[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+// scanIdentifier returns the end of the identifier starting at start
+func scanIdentifier(expr string, start int) int {
+	i := start
+	for i < len(expr) {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		if !isIdentifierStart(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentifier reports whether name is a valid function name
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !isIdentifierStart(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSymbol reports whether symbol is a valid operator symbol
+// i.e. it can't be confused with a number, an identifier, a parenthesis, a comma or white space
+func isSymbol(symbol string) bool {
+	if symbol == "" {
+		return false
+	}
+	for _, r := range symbol {
+		if isIdentifierStart(r) || unicode.IsDigit(r) || unicode.IsSpace(r) ||
+			r == '.' || r == '(' || r == ')' || r == ',' || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,20 @@
+package History
+
+import "errors"
+
+var (
+	// ErrNothingToUndo is returned by Undo when there is no recorded action
+	ErrNothingToUndo = errors.New("invalid operation as there is nothing to undo")
+
+	// ErrNothingToRedo is returned by Redo when there is no undone action
+	ErrNothingToRedo = errors.New("invalid operation as there is nothing to redo")
+
+	// ErrTransactionOpen is returned by Begin, Undo and Redo while a transaction is open
+	ErrTransactionOpen = errors.New("invalid operation as a transaction is open")
+
+	// ErrNoTransaction is returned by Commit and Rollback when no transaction is open
+	ErrNoTransaction = errors.New("invalid operation as no transaction is open")
+
+	// ErrNilFunc is returned by the Do and Undo of a Func whose DoFunc or UndoFunc is nil
+	ErrNilFunc = errors.New("invalid operation as a Func closure is nil")
+)
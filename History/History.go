@@ -0,0 +1,278 @@
+package History
+
+import (
+	"errors"
+
+	"github.com/FahimSifnatul/goDataStructures/Stack"
+)
+
+// Command an action which can be applied and reverted
+// Do is called when the action is recorded and on Redo, Undo is called on Undo and Rollback
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+// Func a Command made of two closures
+// e.g. History.Func{DoFunc: func() error { doc.Insert(pos, text); return nil }, UndoFunc: ...}
+// both closures are required, Do and Undo return ErrNilFunc (without calling anything) if one is nil,
+// so a Func which couldn't be undone is never recorded
+type Func struct {
+	DoFunc   func() error
+	UndoFunc func() error
+}
+
+func (f Func) Do() error {
+	if f.DoFunc == nil || f.UndoFunc == nil {
+		return ErrNilFunc
+	}
+	return f.DoFunc()
+}
+
+func (f Func) Undo() error {
+	if f.DoFunc == nil || f.UndoFunc == nil {
+		return ErrNilFunc
+	}
+	return f.UndoFunc()
+}
+
+// New a global function which creates, initializes and returns an empty history instance
+// maxDepth is the number of actions which can be undone, the oldest ones are forgotten beyond it
+// maxDepth <= 0 means the depth isn't capped
+func New[C Command](maxDepth int) *history[C] {
+	// transactions are pointers, which Stack only accepts with an equal function
+	samePointer := Stack.WithEqual(func(a, b interface{}) bool { return a == b })
+	return &history[C]{
+		undos:    Stack.Stack(samePointer, Stack.WithCapacity(maxDepth, Stack.DropBottom)),
+		redos:    Stack.Stack(samePointer),
+		maxDepth: maxDepth,
+	}
+}
+
+// transaction the commands recorded as a single action, in the order they were done
+type transaction[C Command] struct {
+	commands []C
+}
+
+// stack the methods of the stacks of history, as Stack() returns an unexported type
+type stack interface {
+	Push(elem ...interface{}) error
+	Pop() error
+	Top() (interface{}, error)
+	RemoveAll()
+	Size() int
+	Empty() bool
+}
+
+// history records the done actions on the undos stack and the undone ones on the redos stack,
+// the latest action on top of each
+// the undos stack is bounded by maxDepth with the DropBottom policy, so recording beyond it forgets the oldest action
+// every action is a transaction, Do outside of a transaction records a transaction of a single command
+type history[C Command] struct {
+	undos    stack
+	redos    stack
+	open     *transaction[C]
+	maxDepth int
+}
+
+// historyMethods stores interface declaration of all history methods
+type historyMethods[C Command] interface {
+	// Do applies the command and records it, the redo history is cleared as it no longer applies
+	// inside a transaction the command becomes part of the transaction and the redo history
+	// is kept until the transaction is committed (it still applies if the transaction is rolled back)
+	// returns the error of cmd.Do() and records nothing if it fails
+	Do(cmd C) error
+
+	// Undo reverts the latest action i.e. every command of it from the last one to the first one
+	// if a command fails to undo, the already undone commands of the action are done again,
+	// the action stays undoable and the error is returned
+	// returns error if there is nothing to undo or a transaction is open
+	Undo() error
+
+	// Redo applies the latest undone action again, a failure is handled like Undo
+	// returns error if there is nothing to redo or a transaction is open
+	Redo() error
+
+	// CanUndo checks whether there is an action to undo
+	CanUndo() bool
+
+	// CanRedo checks whether there is an action to redo
+	CanRedo() bool
+
+	// Begin opens a transaction, the commands done until Commit are undone and redone as a single action
+	// returns error if a transaction is already open
+	Begin() error
+
+	// Commit closes the transaction and records it, clearing the redo history like Do
+	// an empty transaction is not recorded and keeps the redo history
+	// returns error if no transaction is open
+	Commit() error
+
+	// Rollback undoes the commands of the transaction from the last one to the first one and discards it
+	// if a command fails to undo, the already undone commands are done again, the transaction stays open
+	// and the error is returned
+	// returns error if no transaction is open
+	Rollback() error
+
+	// UndoSize returns the number of actions which can be undone
+	UndoSize() int
+
+	// RedoSize returns the number of actions which can be redone
+	RedoSize() int
+
+	// MaxDepth returns the depth cap given to New, <= 0 if the depth isn't capped
+	MaxDepth() int
+
+	// Clear forgets every recorded action and discards the open transaction (if any) without undoing it
+	Clear()
+
+	// private methods (for internal use only)
+
+	// record pushes the transaction on the undos, the oldest action is forgotten beyond maxDepth
+	// returns the error of the undos stack, which never rejects a transaction
+	record(tx *transaction[C]) error
+}
+
+func (h *history[C]) Do(cmd C) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+
+	if h.open != nil {
+		h.open.commands = append(h.open.commands, cmd)
+		return nil
+	}
+	h.redos.RemoveAll()
+	return h.record(&transaction[C]{commands: []C{cmd}})
+}
+
+func (h *history[C]) Undo() error {
+	if h.open != nil {
+		return ErrTransactionOpen
+	}
+	top, err := h.undos.Top()
+	if err != nil {
+		return ErrNothingToUndo
+	}
+
+	tx := top.(*transaction[C])
+	if err := undoCommands(tx.commands); err != nil {
+		return err
+	}
+	_ = h.undos.Pop()
+	return h.redos.Push(tx)
+}
+
+func (h *history[C]) Redo() error {
+	if h.open != nil {
+		return ErrTransactionOpen
+	}
+	top, err := h.redos.Top()
+	if err != nil {
+		return ErrNothingToRedo
+	}
+
+	tx := top.(*transaction[C])
+	if err := doCommands(tx.commands); err != nil {
+		return err
+	}
+	_ = h.redos.Pop()
+	return h.record(tx)
+}
+
+func (h *history[C]) CanUndo() bool {
+	return h.open == nil && !h.undos.Empty()
+}
+
+func (h *history[C]) CanRedo() bool {
+	return h.open == nil && !h.redos.Empty()
+}
+
+func (h *history[C]) Begin() error {
+	if h.open != nil {
+		return ErrTransactionOpen
+	}
+
+	h.open = &transaction[C]{commands: make([]C, 0)}
+	return nil
+}
+
+func (h *history[C]) Commit() error {
+	if h.open == nil {
+		return ErrNoTransaction
+	}
+
+	tx := h.open
+	h.open = nil
+	if len(tx.commands) == 0 {
+		return nil
+	}
+	h.redos.RemoveAll()
+	return h.record(tx)
+}
+
+func (h *history[C]) Rollback() error {
+	if h.open == nil {
+		return ErrNoTransaction
+	}
+
+	if err := undoCommands(h.open.commands); err != nil {
+		return err
+	}
+	h.open = nil
+	return nil
+}
+
+func (h *history[C]) UndoSize() int {
+	return h.undos.Size()
+}
+
+func (h *history[C]) RedoSize() int {
+	return h.redos.Size()
+}
+
+func (h *history[C]) MaxDepth() int {
+	return h.maxDepth
+}
+
+func (h *history[C]) Clear() {
+	h.undos.RemoveAll()
+	h.redos.RemoveAll()
+	h.open = nil
+}
+
+func (h *history[C]) record(tx *transaction[C]) error {
+	return h.undos.Push(tx)
+}
+
+// undoCommands undoes the commands from the last one to the first one
+// if a command fails, the commands undone before it are done again so the commands stay all done
+func undoCommands[C Command](commands []C) error {
+	for i := len(commands) - 1; i >= 0; i-- {
+		if err := commands[i].Undo(); err != nil {
+			for _, cmd := range commands[i+1:] {
+				if redoErr := cmd.Do(); redoErr != nil {
+					return errors.Join(err, redoErr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// doCommands does the commands from the first one to the last one
+// if a command fails, the commands done before it are undone again so the commands stay all undone
+func doCommands[C Command](commands []C) error {
+	for i, cmd := range commands {
+		if err := cmd.Do(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if undoErr := commands[j].Undo(); undoErr != nil {
+					return errors.Join(err, undoErr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package History
+
+import (
+	"errors"
+	"testing"
+)
+
+// counter a Func adding delta to *value
+func counter(value *int, delta int) Func {
+	return Func{
+		DoFunc:   func() error { *value += delta; return nil },
+		UndoFunc: func() error { *value -= delta; return nil },
+	}
+}
+
+func TestHistoryTransactionKeepsRedos(t *testing.T) {
+	value := 0
+	h := New[Func](0)
+	_ = h.Do(counter(&value, 1))
+	_ = h.Do(counter(&value, 10))
+	if err := h.Undo(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a rolled back transaction leaves the redo history as it was
+	_ = h.Begin()
+	_ = h.Do(counter(&value, 100))
+	if h.RedoSize() != 1 {
+		t.Fatalf("RedoSize() = %d inside a transaction, want 1", h.RedoSize())
+	}
+	if err := h.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if !h.CanRedo() {
+		t.Fatal("Rollback cleared the redo history")
+	}
+
+	// so does an empty transaction
+	_ = h.Begin()
+	_ = h.Commit()
+	if !h.CanRedo() {
+		t.Fatal("an empty Commit cleared the redo history")
+	}
+	if err := h.Redo(); err != nil || value != 11 {
+		t.Fatalf("Redo() = %v with value %d, want nil with 11", err, value)
+	}
+
+	// a committed transaction clears it
+	_ = h.Undo()
+	_ = h.Begin()
+	_ = h.Do(counter(&value, 100))
+	_ = h.Commit()
+	if h.CanRedo() {
+		t.Fatal("Commit kept the redo history")
+	}
+}
+
+func TestHistoryMaxDepthForgetsOldest(t *testing.T) {
+	value := 0
+	h := New[Func](3)
+	for i := 1; i <= 10; i++ {
+		_ = h.Do(counter(&value, i))
+	}
+	if h.UndoSize() != 3 {
+		t.Fatalf("UndoSize() = %d, want 3", h.UndoSize())
+	}
+	for h.CanUndo() {
+		if err := h.Undo(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// only 10, 9 and 8 were undone
+	if want := 55 - 10 - 9 - 8; value != want {
+		t.Fatalf("value = %d after undoing everything, want %d", value, want)
+	}
+	for h.CanRedo() {
+		_ = h.Redo()
+	}
+	if h.UndoSize() != 3 || value != 55 {
+		t.Fatalf("UndoSize() = %d with value %d after redoing everything, want 3 with 55", h.UndoSize(), value)
+	}
+}
+
+func TestHistoryRejectsNilFunc(t *testing.T) {
+	called := false
+	h := New[Func](0)
+	cmds := []Func{
+		{},
+		{DoFunc: func() error { called = true; return nil }},
+		{UndoFunc: func() error { called = true; return nil }},
+	}
+	for i, cmd := range cmds {
+		if err := h.Do(cmd); !errors.Is(err, ErrNilFunc) {
+			t.Fatalf("Do(cmds[%d]) = %v, want ErrNilFunc", i, err)
+		}
+	}
+	if called || h.CanUndo() {
+		t.Fatalf("a Func with a nil closure was called (%t) or recorded (%t)", called, h.CanUndo())
+	}
+}
+
+func TestHistoryFailedUndoStaysUndoable(t *testing.T) {
+	value := 0
+	fail := errors.New("fail")
+	failing := true
+	h := New[Func](0)
+	_ = h.Begin()
+	_ = h.Do(counter(&value, 1))
+	_ = h.Do(Func{
+		DoFunc: func() error { value += 10; return nil },
+		UndoFunc: func() error {
+			if failing {
+				return fail
+			}
+			value -= 10
+			return nil
+		},
+	})
+	_ = h.Commit()
+
+	if err := h.Undo(); !errors.Is(err, fail) {
+		t.Fatalf("Undo() = %v, want %v", err, fail)
+	}
+	if value != 11 || !h.CanUndo() || h.CanRedo() {
+		t.Fatalf("failed Undo left value %d, CanUndo %t, CanRedo %t", value, h.CanUndo(), h.CanRedo())
+	}
+	failing = false
+	if err := h.Undo(); err != nil || value != 0 {
+		t.Fatalf("Undo() = %v with value %d, want nil with 0", err, value)
+	}
+}
@@ -0,0 +1,64 @@
+package PriorityQueue
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmpty is returned when an element is requested from or popped from an empty priority queue
+	ErrEmpty = errors.New("invalid operation as priority queue is empty")
+
+	// ErrCountExceedsSize is matched (with errors.Is) by the errors returned when
+	// the requested element count is bigger than the size of the priority queue
+	ErrCountExceedsSize = errors.New("invalid operation as count is greater than the priority queue size")
+
+	// ErrNegativeCount is matched (with errors.Is) by the errors returned when the requested element count is negative
+	ErrNegativeCount = errors.New("invalid operation as count is negative")
+
+	// ErrInvalidHandle is returned by UpdatePriority and Remove when the handle doesn't belong to the priority queue
+	// e.g. its element is already popped or removed
+	ErrInvalidHandle = errors.New("invalid handle as it doesn't belong to the priority queue")
+)
+
+// countExceedsSizeError describes which count (pop, front, ...) is bigger than the priority queue size
+// it matches ErrCountExceedsSize
+type countExceedsSizeError struct {
+	op    string
+	count int
+	size  int
+}
+
+func (e *countExceedsSizeError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is greater than the priority queue size(%d)", e.op, e.count, e.size)
+}
+
+func (e *countExceedsSizeError) Is(target error) bool {
+	return target == ErrCountExceedsSize
+}
+
+// negativeCountError describes which count (pop, front, ...) is negative
+// it matches ErrNegativeCount
+type negativeCountError struct {
+	op    string
+	count int
+}
+
+func (e *negativeCountError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is negative", e.op, e.count)
+}
+
+func (e *negativeCountError) Is(target error) bool {
+	return target == ErrNegativeCount
+}
+
+// checkCount returns error if the count of the op is negative or bigger than the size of the priority queue
+func checkCount(op string, count, size int) error {
+	if count < 0 {
+		return &negativeCountError{op: op, count: count}
+	}
+	if count > size {
+		return &countExceedsSizeError{op: op, count: count, size: size}
+	}
+	return nil
+}
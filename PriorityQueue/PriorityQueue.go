@@ -0,0 +1,402 @@
+package PriorityQueue
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// New a global function which creates, initializes and returns a priority queue instance
+// less decides the ordering, Front() is the element which is less than every other element
+// e.g. less = func(a, b int) bool { return a < b } makes a min priority queue
+// elements having the same priority (neither is less than the other) leave the queue in the order they were pushed
+// elements are compared with == (used by Search)
+func New[T comparable](less func(a, b T) bool) *priorityQueue[T] {
+	return NewWithEqual[T](less, func(a, b T) bool { return a == b })
+}
+
+// NewWithEqual creates, initializes and returns a priority queue instance
+// for element types which are not comparable with ==
+// equal is used by Search to compare two elements
+func NewWithEqual[T any](less, equal func(a, b T) bool) *priorityQueue[T] {
+	return &priorityQueue[T]{
+		heap:  make([]*Handle[T], 0),
+		less:  less,
+		equal: equal,
+	}
+}
+
+// Handle refers to an element pushed to a priority queue
+// it is used to update the priority of the element or remove the element in O(log n)
+// seq is the push order of the element, it breaks the ties between elements having the same priority
+type Handle[T any] struct {
+	elem  T
+	index int
+	seq   uint64
+	queue *priorityQueue[T]
+}
+
+// Value returns the element the handle refers to
+func (h *Handle[T]) Value() T {
+	return h.elem
+}
+
+// priorityQueue where priority queue data are stored as a binary heap ordered by before
+// heap[0] is the front element and every handle knows its own index in heap
+type priorityQueue[T any] struct {
+	heap    []*Handle[T]
+	less    func(a, b T) bool
+	equal   func(a, b T) bool
+	nextSeq uint64
+}
+
+type priorityQueueMethods[T any] interface {
+	// global methods
+
+	// Push adds one or more elements to an existing priority queue
+	Push(elem ...T)
+
+	// PushHandle adds an element to an existing priority queue
+	// and returns the handle used by UpdatePriority and Remove
+	PushHandle(elem T) *Handle[T]
+
+	// Pop removes the front element i.e. the highest priority element from the priority queue
+	Pop() error
+
+	// Pops can pop multiple elements from the front of the priority queue
+	// returns error if the popCount number is negative or bigger than the size of the priority queue
+	Pops(popCount int) error
+
+	// RemoveAll removes all elements from the caller priority queue
+	// handles of the removed elements become invalid
+	RemoveAll()
+
+	// Front returns the front element i.e. the highest priority element from the priority queue
+	// and error (if priority queue is empty)
+	Front() (T, error)
+
+	// Fronts returns the highest priority elements equal to frontCount (stored in a slice)
+	// in the order FrontsAndPops would return them, in O(frontCount log frontCount), and error (if any)
+	Fronts(frontCount int) ([]T, error)
+
+	// FrontAndPop retrieves the Front() element from the priority queue
+	// returns the front element and also Pop() from the priority queue
+	// also returns error (if any)
+	FrontAndPop() (T, error)
+
+	// FrontsAndPops returns the highest priority elements equal to count (stored in a slice)
+	// and also pop those elements from the priority queue
+	// and error (if any)
+	FrontsAndPops(count int) ([]T, error)
+
+	// UpdatePriority replaces the element referred by the handle with elem
+	// and moves it to its new position according to less, the element keeps its push order
+	// returns ErrInvalidHandle if the handle doesn't belong to the priority queue
+	UpdatePriority(h *Handle[T], elem T) error
+
+	// Remove removes the element referred by the handle from the priority queue
+	// returns ErrInvalidHandle if the handle doesn't belong to the priority queue
+	Remove(h *Handle[T]) error
+
+	// Size returns the size of an existing priority queue
+	Size() int
+
+	// Empty checks whether the priority queue is empty or not
+	// returns true if empty else false
+	Empty() bool
+
+	// Search finds the parametric element in the priority queue
+	// if the element is found then returns the position from the Front else -1 (not found) in O(n)
+	// N.B. Front() is taken as position 1
+	Search(elem T) int
+
+	// Display prints the priority queue value as slice on console screen
+	// the left most data is the highest priority element
+	Display()
+
+	// ToSlice returns the priority queue as slice ordered from the highest priority
+	ToSlice() []T
+
+	// private methods (for internal use only)
+
+	// removeAt removes the element at index i of the heap and returns its handle
+	removeAt(i int) *Handle[T]
+
+	// up moves the element at index i of the heap towards the root until the heap is valid
+	up(i int)
+
+	// down moves the element at index i of the heap towards the leaves until the heap is valid
+	// returns true if the element is moved
+	down(i int) bool
+
+	// swap swaps the elements at index i and j of the heap and fixes their handle indexes
+	swap(i, j int)
+
+	// before checks whether the element of a leaves the queue before the element of b
+	// i.e. it has a higher priority, or the same priority and was pushed earlier
+	before(a, b *Handle[T]) bool
+
+	// firsts returns the count first elements in the order they leave the queue, without changing the heap
+	firsts(count int) []T
+}
+
+func (pq *priorityQueue[T]) Push(elem ...T) {
+	for _, e := range elem {
+		pq.PushHandle(e)
+	}
+}
+
+func (pq *priorityQueue[T]) PushHandle(elem T) *Handle[T] {
+	h := &Handle[T]{
+		elem:  elem,
+		index: len(pq.heap),
+		seq:   pq.nextSeq,
+		queue: pq,
+	}
+	pq.nextSeq++
+	pq.heap = append(pq.heap, h)
+	pq.up(h.index)
+	return h
+}
+
+func (pq *priorityQueue[T]) Pop() error {
+	if pq.Empty() {
+		return ErrEmpty
+	}
+
+	pq.removeAt(0)
+	return nil
+}
+
+func (pq *priorityQueue[T]) Pops(popCount int) error {
+	if err := checkCount("pop", popCount, pq.Size()); err != nil {
+		return err
+	}
+
+	for i := 0; i < popCount; i++ {
+		pq.removeAt(0)
+	}
+	return nil
+}
+
+func (pq *priorityQueue[T]) RemoveAll() {
+	for _, h := range pq.heap {
+		h.queue = nil
+	}
+	pq.heap = make([]*Handle[T], 0)
+}
+
+func (pq *priorityQueue[T]) Front() (T, error) {
+	if pq.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return pq.heap[0].elem, nil
+}
+
+func (pq *priorityQueue[T]) Fronts(frontCount int) ([]T, error) {
+	if err := checkCount("front", frontCount, pq.Size()); err != nil {
+		return nil, err
+	}
+
+	return pq.firsts(frontCount), nil
+}
+
+func (pq *priorityQueue[T]) FrontAndPop() (T, error) {
+	elem, err := pq.Front()
+	if err != nil {
+		return elem, err
+	}
+	pq.removeAt(0)
+	return elem, nil
+}
+
+func (pq *priorityQueue[T]) FrontsAndPops(count int) ([]T, error) {
+	if err := checkCount("front", count, pq.Size()); err != nil {
+		return nil, err
+	}
+
+	elemSlice := make([]T, count)
+	for i := range elemSlice {
+		elemSlice[i] = pq.removeAt(0).elem
+	}
+	return elemSlice, nil
+}
+
+func (pq *priorityQueue[T]) UpdatePriority(h *Handle[T], elem T) error {
+	if h == nil || h.queue != pq {
+		return ErrInvalidHandle
+	}
+
+	h.elem = elem
+	if !pq.down(h.index) {
+		pq.up(h.index)
+	}
+	return nil
+}
+
+func (pq *priorityQueue[T]) Remove(h *Handle[T]) error {
+	if h == nil || h.queue != pq {
+		return ErrInvalidHandle
+	}
+
+	pq.removeAt(h.index)
+	return nil
+}
+
+func (pq *priorityQueue[T]) Size() int {
+	return len(pq.heap)
+}
+
+func (pq *priorityQueue[T]) Empty() bool {
+	return pq.Size() == 0
+}
+
+// Search finds the first matching element in the heap order, then counts the elements leaving the queue before it
+func (pq *priorityQueue[T]) Search(elem T) int {
+	var found *Handle[T]
+	for _, h := range pq.heap {
+		if pq.equal(h.elem, elem) && (found == nil || pq.before(h, found)) {
+			found = h
+		}
+	}
+	if found == nil {
+		return -1
+	}
+
+	position := 1
+	for _, h := range pq.heap {
+		if pq.before(h, found) {
+			position++
+		}
+	}
+	return position
+}
+
+func (pq *priorityQueue[T]) Display() {
+	fmt.Println(pq.ToSlice())
+}
+
+func (pq *priorityQueue[T]) ToSlice() []T {
+	return pq.firsts(pq.Size())
+}
+
+func (pq *priorityQueue[T]) removeAt(i int) *Handle[T] {
+	last := len(pq.heap) - 1
+	if i != last {
+		pq.swap(i, last)
+	}
+
+	h := pq.heap[last]
+	pq.heap[last] = nil
+	pq.heap = pq.heap[:last]
+	if i != last && !pq.down(i) {
+		pq.up(i)
+	}
+
+	h.queue = nil
+	h.index = -1
+	return h
+}
+
+func (pq *priorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.before(pq.heap[i], pq.heap[parent]) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+func (pq *priorityQueue[T]) down(i int) bool {
+	start, n := i, len(pq.heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.before(pq.heap[right], pq.heap[child]) {
+			child = right
+		}
+		if !pq.before(pq.heap[child], pq.heap[i]) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+func (pq *priorityQueue[T]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.heap[i].index = i
+	pq.heap[j].index = j
+}
+
+func (pq *priorityQueue[T]) before(a, b *Handle[T]) bool {
+	if pq.less(a.elem, b.elem) {
+		return true
+	}
+	if pq.less(b.elem, a.elem) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+// firsts takes the elements best-first from a heap of candidate indexes starting at the root,
+// every taken index adds its children as candidates, so only O(count) indexes are ever looked at
+// when all elements are asked for, they are sorted directly instead
+func (pq *priorityQueue[T]) firsts(count int) []T {
+	elemSlice := make([]T, 0, count)
+	if count == len(pq.heap) {
+		handles := append([]*Handle[T](nil), pq.heap...)
+		sort.Slice(handles, func(i, j int) bool { return pq.before(handles[i], handles[j]) })
+		for _, h := range handles {
+			elemSlice = append(elemSlice, h.elem)
+		}
+		return elemSlice
+	}
+
+	candidates := &candidateHeap[T]{pq: pq, indexes: []int{0}}
+	for len(elemSlice) < count {
+		i := heap.Pop(candidates).(int)
+		elemSlice = append(elemSlice, pq.heap[i].elem)
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(pq.heap) {
+				heap.Push(candidates, child)
+			}
+		}
+	}
+	return elemSlice
+}
+
+// candidateHeap a min-heap of indexes of pq.heap ordered like the heap itself, used through container/heap
+type candidateHeap[T any] struct {
+	pq      *priorityQueue[T]
+	indexes []int
+}
+
+func (c *candidateHeap[T]) Len() int {
+	return len(c.indexes)
+}
+
+func (c *candidateHeap[T]) Less(i, j int) bool {
+	return c.pq.before(c.pq.heap[c.indexes[i]], c.pq.heap[c.indexes[j]])
+}
+
+func (c *candidateHeap[T]) Swap(i, j int) {
+	c.indexes[i], c.indexes[j] = c.indexes[j], c.indexes[i]
+}
+
+func (c *candidateHeap[T]) Push(x any) {
+	c.indexes = append(c.indexes, x.(int))
+}
+
+func (c *candidateHeap[T]) Pop() any {
+	last := c.indexes[len(c.indexes)-1]
+	c.indexes = c.indexes[:len(c.indexes)-1]
+	return last
+}
@@ -0,0 +1,213 @@
+package PriorityQueue
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueueTypedErrors(t *testing.T) {
+	pq := New(func(a, b int) bool { return a < b })
+	if _, err := pq.Front(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Front() = %v, want ErrEmpty", err)
+	}
+	if err := pq.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Pop() = %v, want ErrEmpty", err)
+	}
+
+	h := pq.PushHandle(2)
+	pq.Push(3, 1)
+	if err := pq.Pops(4); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("Pops(4) = %v, want ErrCountExceedsSize", err)
+	}
+	if _, err := pq.Fronts(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Fronts(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := pq.FrontsAndPops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("FrontsAndPops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if pq.Size() != 3 {
+		t.Fatalf("rejected counts changed the size to %d", pq.Size())
+	}
+
+	if err := pq.Remove(h); err != nil {
+		t.Fatal(err)
+	}
+	if err := pq.Remove(h); !errors.Is(err, ErrInvalidHandle) {
+		t.Fatalf("second Remove() = %v, want ErrInvalidHandle", err)
+	}
+	if err := New(func(a, b int) bool { return a < b }).UpdatePriority(pq.PushHandle(5), 0); !errors.Is(err, ErrInvalidHandle) {
+		t.Fatalf("UpdatePriority of another queue's handle = %v, want ErrInvalidHandle", err)
+	}
+}
+
+// checkHeap checks that every element is not less than its parent and every handle knows its index
+func checkHeap[T any](t *testing.T, pq *priorityQueue[T]) {
+	t.Helper()
+	for i, h := range pq.heap {
+		if h.index != i || h.queue != pq {
+			t.Fatalf("handle at %d has index %d", i, h.index)
+		}
+		if parent := (i - 1) / 2; i > 0 && pq.before(h, pq.heap[parent]) {
+			t.Fatalf("heap[%d] = %v is less than its parent %v", i, h.elem, pq.heap[parent].elem)
+		}
+	}
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pq := New(func(a, b int) bool { return a > b })
+	want := make([]int, 0)
+	for i := 0; i < 500; i++ {
+		if rng.Intn(3) == 0 && len(want) > 0 {
+			front, err := pq.FrontAndPop()
+			if err != nil || front != want[0] {
+				t.Fatalf("step %d: FrontAndPop() = %d, %v, want %d", i, front, err, want[0])
+			}
+			want = want[1:]
+		} else {
+			elem := rng.Intn(50)
+			pq.Push(elem)
+			want = append(want, elem)
+			slices.SortFunc(want, func(a, b int) int { return b - a })
+		}
+		checkHeap(t, pq)
+	}
+	if got := pq.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("ToSlice() = %v, want %v", got, want)
+	}
+	fronts, _ := pq.Fronts(3)
+	if !slices.Equal(fronts, want[:3]) || pq.Size() != len(want) {
+		t.Fatalf("Fronts(3) = %v with Size() %d, want %v with %d", fronts, pq.Size(), want[:3], len(want))
+	}
+	popped, _ := pq.FrontsAndPops(len(want))
+	if !slices.Equal(popped, want) || !pq.Empty() {
+		t.Fatalf("FrontsAndPops() = %v, want %v", popped, want)
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	pq := New(func(a, b int) bool { return a < b })
+	handles := make([]*Handle[int], 0)
+	for i := 0; i < 200; i++ {
+		handles = append(handles, pq.PushHandle(rng.Intn(1000)))
+	}
+
+	for step := 0; step < 200; step++ {
+		i := rng.Intn(len(handles))
+		h := handles[i]
+		if rng.Intn(2) == 0 {
+			// move the element both towards the root and towards the leaves
+			elem := rng.Intn(1000)
+			if err := pq.UpdatePriority(h, elem); err != nil {
+				t.Fatal(err)
+			}
+			if h.Value() != elem {
+				t.Fatalf("step %d: Value() = %d after UpdatePriority, want %d", step, h.Value(), elem)
+			}
+		} else {
+			if err := pq.Remove(h); err != nil {
+				t.Fatal(err)
+			}
+			if err := pq.UpdatePriority(h, 0); !errors.Is(err, ErrInvalidHandle) {
+				t.Fatalf("step %d: UpdatePriority of a removed handle = %v, want ErrInvalidHandle", step, err)
+			}
+			handles = slices.Delete(handles, i, i+1)
+		}
+		checkHeap(t, pq)
+		if pq.Size() != len(handles) {
+			t.Fatalf("step %d: Size() = %d, want %d", step, pq.Size(), len(handles))
+		}
+	}
+
+	want := make([]int, len(handles))
+	for i, h := range handles {
+		want[i] = h.Value()
+	}
+	slices.Sort(want)
+	if got := pq.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("ToSlice() = %v, want %v", got, want)
+	}
+
+	// popped and removed-by-RemoveAll handles are invalid
+	front := pq.heap[0]
+	_ = pq.Pop()
+	if err := pq.Remove(front); !errors.Is(err, ErrInvalidHandle) {
+		t.Fatalf("Remove of a popped handle = %v, want ErrInvalidHandle", err)
+	}
+	last := pq.heap[len(pq.heap)-1]
+	pq.RemoveAll()
+	if err := pq.UpdatePriority(last, 1); !errors.Is(err, ErrInvalidHandle) {
+		t.Fatalf("UpdatePriority after RemoveAll = %v, want ErrInvalidHandle", err)
+	}
+}
+
+func TestPriorityQueueSearch(t *testing.T) {
+	pq := New(func(a, b string) bool { return a < b })
+	pq.Push("c", "a", "b")
+	if pos := pq.Search("b"); pos != 2 {
+		t.Fatalf("Search(b) = %d, want 2", pos)
+	}
+	if pos := pq.Search("z"); pos != -1 {
+		t.Fatalf("Search(z) = %d, want -1", pos)
+	}
+}
+
+// job is pushed with a priority and an id telling the push order
+type job struct {
+	priority, id int
+}
+
+func TestPriorityQueueTies(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	pq := New(func(a, b job) bool { return a.priority < b.priority })
+	handles := make([]*Handle[job], 0)
+	for i := 0; i < 300; i++ {
+		handles = append(handles, pq.PushHandle(job{rng.Intn(5), i}))
+	}
+	for step := 0; step < 100; step++ {
+		i := rng.Intn(len(handles))
+		if rng.Intn(2) == 0 {
+			// the updated element keeps its place among the elements of its new priority
+			elem := job{rng.Intn(5), handles[i].Value().id}
+			if err := pq.UpdatePriority(handles[i], elem); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			if err := pq.Remove(handles[i]); err != nil {
+				t.Fatal(err)
+			}
+			handles = slices.Delete(handles, i, i+1)
+		}
+		checkHeap(t, pq)
+	}
+
+	want := make([]job, len(handles))
+	for i, h := range handles {
+		want[i] = h.Value()
+	}
+	slices.SortFunc(want, func(a, b job) int {
+		if a.priority != b.priority {
+			return a.priority - b.priority
+		}
+		return a.id - b.id
+	})
+	for _, count := range []int{0, 1, 7, len(want) / 2, len(want)} {
+		if fronts, _ := pq.Fronts(count); !slices.Equal(fronts, want[:count]) {
+			t.Fatalf("Fronts(%d) = %v, want %v", count, fronts, want[:count])
+		}
+	}
+	if got := pq.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("ToSlice() = %v, want %v", got, want)
+	}
+	for i, elem := range want {
+		if pos := pq.Search(elem); pos != i+1 {
+			t.Fatalf("Search(%v) = %d, want %d", elem, pos, i+1)
+		}
+	}
+	if popped, _ := pq.FrontsAndPops(len(want)); !slices.Equal(popped, want) {
+		t.Fatalf("FrontsAndPops() = %v, want %v", popped, want)
+	}
+}
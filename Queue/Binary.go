@@ -0,0 +1,61 @@
+package Queue
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Codec"
+
+func (q *queueStruct) MarshalBinary() ([]byte, error) {
+	return Codec.MarshalBinary(q.queueDataKind, q.ToSlice())
+}
+
+func (q *queueStruct) UnmarshalBinary(data []byte) error {
+	kind, elems, err := Codec.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if err := q.checkCapacity(len(elems)); err != nil {
+		return err
+	}
+
+	decoded := Queue()
+	decoded.equal = q.equal
+	decoded.queueDataKind = kind
+	if err := decoded.Push(elems...); err != nil {
+		return err
+	}
+
+	q.queue = decoded.queue
+	q.queueDataKind = decoded.queueDataKind
+	return nil
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (q *queueStruct) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (q *queueStruct) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+func (cq *concurrentQueue) MarshalBinary() ([]byte, error) {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.MarshalBinary()
+}
+
+func (cq *concurrentQueue) UnmarshalBinary(data []byte) error {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.UnmarshalBinary(data)
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (cq *concurrentQueue) GobEncode() ([]byte, error) {
+	return cq.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (cq *concurrentQueue) GobDecode(data []byte) error {
+	return cq.UnmarshalBinary(data)
+}
@@ -0,0 +1,11 @@
+package Queue
+
+import (
+	"testing"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/CodecTest"
+)
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	CodecTest.FuzzUnmarshalBinary(f, func() *queueStruct { return Queue() }, (*queueStruct).Push)
+}
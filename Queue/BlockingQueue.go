@@ -0,0 +1,185 @@
+package Queue
+
+import (
+	"context"
+	"sync"
+)
+
+// NewBlocking a global function which creates, initializes and returns a blocking queue instance
+// Put waits while the queue holds capacity elements, capacity <= 0 means unbounded
+// it accepts the same Option values as Queue()
+func NewBlocking(capacity int, opts ...Option) *blockingQueue {
+	return &blockingQueue{
+		q:        Queue(opts...).unbounded(),
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+}
+
+// blockingQueue a queueStruct shared by producers and consumers running in different goroutines
+// every state change closes the changed channel (and replaces it) to wake up the waiters,
+// so waiting can be combined with context cancellation
+type blockingQueue struct {
+	mu       sync.Mutex
+	q        *queueStruct
+	capacity int
+	closed   bool
+	changed  chan struct{}
+}
+
+type blockingQueueMethods interface {
+	// Take removes and returns the front element of the queue
+	// it waits until an element is available, the queue is closed or ctx is done
+	// elements still in a closed queue can be taken, after that ErrClosed is returned
+	Take(ctx context.Context) (interface{}, error)
+
+	// Put adds an element to the queue
+	// it waits while the queue is at its capacity until the queue is closed or ctx is done
+	// returns ErrClosed if the queue is closed and error if the data type is mismatched
+	Put(ctx context.Context, elem interface{}) error
+
+	// TryTake is the non-blocking form of Take
+	// returns error right away if the queue is empty
+	TryTake() (interface{}, error)
+
+	// TryPut is the non-blocking form of Put
+	// returns ErrFull right away if the queue is at its capacity
+	TryPut(elem interface{}) error
+
+	// Close closes the queue and wakes up every waiting Take and Put
+	// closing an already closed queue does nothing
+	Close()
+
+	// Size returns the number of elements in the queue
+	Size() int
+
+	// Empty checks whether the queue is empty or not
+	Empty() bool
+
+	// Capacity returns the capacity of the queue, 0 or less means unbounded
+	Capacity() int
+
+	// private methods (for internal use only)
+
+	// isFull checks whether the queue is at its capacity, the lock must be held
+	isFull() bool
+
+	// broadcast wakes up every waiter, the lock must be held
+	broadcast()
+}
+
+func (bq *blockingQueue) Take(ctx context.Context) (interface{}, error) {
+	for {
+		bq.mu.Lock()
+		if !bq.q.Empty() {
+			elem, err := bq.q.FrontAndPop()
+			bq.broadcast()
+			bq.mu.Unlock()
+			return elem, err
+		}
+		if bq.closed {
+			bq.mu.Unlock()
+			return nil, ErrClosed
+		}
+		changed := bq.changed
+		bq.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (bq *blockingQueue) Put(ctx context.Context, elem interface{}) error {
+	for {
+		bq.mu.Lock()
+		if bq.closed {
+			bq.mu.Unlock()
+			return ErrClosed
+		}
+		if !bq.isFull() {
+			err := bq.q.Push(elem)
+			if err == nil {
+				bq.broadcast()
+			}
+			bq.mu.Unlock()
+			return err
+		}
+		changed := bq.changed
+		bq.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (bq *blockingQueue) TryTake() (interface{}, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if bq.closed && bq.q.Empty() {
+		return nil, ErrClosed
+	}
+	elem, err := bq.q.FrontAndPop()
+	if err != nil {
+		return nil, err
+	}
+	bq.broadcast()
+	return elem, nil
+}
+
+func (bq *blockingQueue) TryPut(elem interface{}) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if bq.closed {
+		return ErrClosed
+	}
+	if bq.isFull() {
+		return ErrFull
+	}
+	if err := bq.q.Push(elem); err != nil {
+		return err
+	}
+	bq.broadcast()
+	return nil
+}
+
+func (bq *blockingQueue) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if bq.closed {
+		return
+	}
+	bq.closed = true
+	bq.broadcast()
+}
+
+func (bq *blockingQueue) Size() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.q.Size()
+}
+
+func (bq *blockingQueue) Empty() bool {
+	return bq.Size() == 0
+}
+
+func (bq *blockingQueue) Capacity() int {
+	return bq.capacity
+}
+
+func (bq *blockingQueue) isFull() bool {
+	return bq.capacity > 0 && bq.q.Size() >= bq.capacity
+}
+
+func (bq *blockingQueue) broadcast() {
+	close(bq.changed)
+	bq.changed = make(chan struct{})
+}
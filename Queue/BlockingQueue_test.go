@@ -0,0 +1,176 @@
+package Queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// result runs f in a goroutine and returns the channel receiving its error
+func result(f func() error) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- f() }()
+	return done
+}
+
+// mustWait fails if done receives before a short delay, i.e. if the call didn't block
+func mustWait(t *testing.T, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		t.Fatalf("the call returned %v instead of waiting", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// mustReturn waits for done and returns the error of the call
+func mustReturn(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiting call wasn't woken up")
+		return nil
+	}
+}
+
+func TestBlockingQueueTakeWakesOnPut(t *testing.T) {
+	bq := NewBlocking(0)
+	var taken interface{}
+	done := result(func() (err error) {
+		taken, err = bq.Take(context.Background())
+		return err
+	})
+	mustWait(t, done)
+
+	if err := bq.Put(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	if err := mustReturn(t, done); err != nil || taken != 7 {
+		t.Fatalf("Take() = %v, %v, want 7, nil", taken, err)
+	}
+}
+
+func TestBlockingQueuePutWakesOnTake(t *testing.T) {
+	bq := NewBlocking(1)
+	_ = bq.TryPut(1)
+	if err := bq.TryPut(2); !errors.Is(err, ErrFull) {
+		t.Fatalf("TryPut() on a full queue = %v, want ErrFull", err)
+	}
+	done := result(func() error { return bq.Put(context.Background(), 2) })
+	mustWait(t, done)
+
+	if elem, err := bq.TryTake(); err != nil || elem != 1 {
+		t.Fatalf("TryTake() = %v, %v, want 1, nil", elem, err)
+	}
+	if err := mustReturn(t, done); err != nil {
+		t.Fatal(err)
+	}
+	if elem, _ := bq.TryTake(); elem != 2 {
+		t.Fatalf("TryTake() = %v, want 2", elem)
+	}
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	empty, full := NewBlocking(0), NewBlocking(1)
+	_ = full.TryPut(1)
+	take := result(func() error { _, err := empty.Take(context.Background()); return err })
+	put := result(func() error { return full.Put(context.Background(), 2) })
+	mustWait(t, take)
+	mustWait(t, put)
+
+	empty.Close()
+	full.Close()
+	full.Close()
+	if err := mustReturn(t, take); !errors.Is(err, ErrClosed) {
+		t.Fatalf("waiting Take() = %v, want ErrClosed", err)
+	}
+	if err := mustReturn(t, put); !errors.Is(err, ErrClosed) {
+		t.Fatalf("waiting Put() = %v, want ErrClosed", err)
+	}
+
+	// the elements left in a closed queue can still be taken
+	if err := full.TryPut(3); !errors.Is(err, ErrClosed) {
+		t.Fatalf("TryPut() on a closed queue = %v, want ErrClosed", err)
+	}
+	if elem, err := full.Take(context.Background()); err != nil || elem != 1 {
+		t.Fatalf("Take() = %v, %v, want 1, nil", elem, err)
+	}
+	if _, err := full.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Take() on a drained closed queue = %v, want ErrClosed", err)
+	}
+	if _, err := full.TryTake(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("TryTake() on a drained closed queue = %v, want ErrClosed", err)
+	}
+}
+
+func TestBlockingQueueContextCancel(t *testing.T) {
+	bq := NewBlocking(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	take := result(func() error { _, err := bq.Take(ctx); return err })
+	mustWait(t, take)
+	cancel()
+	if err := mustReturn(t, take); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take() = %v, want context.Canceled", err)
+	}
+
+	_ = bq.TryPut(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bq.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Put() = %v, want context.DeadlineExceeded", err)
+	}
+	if bq.Size() != 1 {
+		t.Fatalf("Size() = %d after a cancelled Put, want 1", bq.Size())
+	}
+}
+
+func TestBlockingQueueProducersConsumers(t *testing.T) {
+	bq := NewBlocking(4)
+	const producers, perProducer = 4, 250
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := bq.Put(context.Background(), p*perProducer+i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var consumers sync.WaitGroup
+	for c := 0; c < 3; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				elem, err := bq.Take(context.Background())
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				mu.Lock()
+				if seen[elem.(int)] {
+					t.Errorf("%v was taken twice", elem)
+				}
+				seen[elem.(int)] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	bq.Close()
+	consumers.Wait()
+	if len(seen) != producers*perProducer {
+		t.Fatalf("%d elements were taken, want %d", len(seen), producers*perProducer)
+	}
+}
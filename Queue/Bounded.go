@@ -0,0 +1,106 @@
+package Queue
+
+import (
+	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Bounded"
+)
+
+// OverflowPolicy decides what Push does when the queue is at its capacity (see WithCapacity)
+type OverflowPolicy int
+
+const (
+	// Reject returns an *OverflowError (matching ErrFull) and doesn't push any element
+	Reject = OverflowPolicy(Bounded.Reject)
+
+	// DropOldest removes as many Front() i.e. earliest inserted elements as needed to push the new ones
+	DropOldest = OverflowPolicy(Bounded.DropOldest)
+
+	// DropIncoming discards the pushed elements without an error
+	DropIncoming = OverflowPolicy(Bounded.DropIncoming)
+
+	// Block makes the Push of the queue created by NewConcurrent wait until there is room for every pushed element
+	// a queue created by Queue() has no other goroutine to make room, so it behaves like Reject
+	Block = OverflowPolicy(Bounded.Block)
+)
+
+// OverflowStats counts the elements handled by the overflow policy since the queue was created
+type OverflowStats struct {
+	// Rejected is the number of elements of the rejected pushes
+	Rejected int
+
+	// DroppedOldest is the number of earliest inserted elements removed by DropOldest
+	DroppedOldest int
+
+	// DroppedIncoming is the number of pushed elements discarded by DropIncoming
+	DroppedIncoming int
+}
+
+// WithCapacity limits the number of elements of the queue to capacity, capacity <= 0 means unbounded
+// policy decides what Push does when the pushed elements don't fit, a Push is still all-or-nothing:
+// the elements are either pushed together or not at all, and pushing more elements than capacity
+// at once is always rejected as they can never fit
+// NewBlocking, NewReliable and NewDelay ignore it as they decide themselves when an element is added
+func WithCapacity(capacity int, policy OverflowPolicy) Option {
+	return func(q *queueStruct) {
+		q.limit.Capacity = capacity
+		q.limit.Policy = Bounded.Policy(policy)
+	}
+}
+
+func (q *queueStruct) Capacity() int {
+	return q.limit.Capacity
+}
+
+func (q *queueStruct) Overflows() OverflowStats {
+	return OverflowStats(q.limit.Stats)
+}
+
+func (q *queueStruct) makeRoom(count int) (bool, error) {
+	queueSize := q.Size()
+	dropCount, push, rejected := q.limit.MakeRoom(queueSize, count)
+	if rejected {
+		return false, &OverflowError{Capacity: q.limit.Capacity, Size: queueSize, Count: count}
+	}
+
+	// DropOldest
+	if dropCount > 0 {
+		q.queue.PopFronts(dropCount)
+	}
+	return push, nil
+}
+
+func (q *queueStruct) checkCapacity(count int) error {
+	if !q.limit.Fits(count) {
+		return &OverflowError{Capacity: q.limit.Capacity, Size: 0, Count: count}
+	}
+	return nil
+}
+
+func (q *queueStruct) mustWait(elem []interface{}) bool {
+	if !q.limit.MustWait(q.Size(), len(elem)) {
+		return false
+	}
+	// a push which fails anyway returns its error right away instead of waiting
+	for _, e := range elem {
+		if reflect.TypeOf(e).Kind() != q.queueDataKind {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *queueStruct) unbounded() *queueStruct {
+	q.limit.Capacity = 0
+	return q
+}
+
+func (cq *concurrentQueue) Capacity() int {
+	return cq.q.Capacity()
+}
+
+func (cq *concurrentQueue) Overflows() OverflowStats {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Overflows()
+}
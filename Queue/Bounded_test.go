@@ -0,0 +1,71 @@
+package Queue
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestQueueDropOldest(t *testing.T) {
+	q := Queue(WithCapacity(3, DropOldest))
+	_ = q.Push(1, 2, 3)
+	if err := q.Push(4, 5); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{3, 4, 5}) {
+		t.Fatalf("queue %v, want [3 4 5]", got)
+	}
+	if got := q.Overflows(); got != (OverflowStats{DroppedOldest: 2}) {
+		t.Fatalf("Overflows() = %+v, want 2 oldest dropped", got)
+	}
+}
+
+func TestQueueDropIncoming(t *testing.T) {
+	q := Queue(WithCapacity(3, DropIncoming))
+	_ = q.Push(1, 2)
+	if err := q.Push(3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatalf("queue %v, want [1 2]", got)
+	}
+	if err := q.Push(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Overflows(); got != (OverflowStats{DroppedIncoming: 2}) {
+		t.Fatalf("Overflows() = %+v, want 2 incoming dropped", got)
+	}
+}
+
+func TestQueueReject(t *testing.T) {
+	for _, policy := range []OverflowPolicy{Reject, Block} {
+		q := Queue(WithCapacity(3, policy))
+		_ = q.Push(1, 2)
+		var overflow *OverflowError
+		if err := q.Push(3, 4); !errors.As(err, &overflow) || !errors.Is(err, ErrFull) {
+			t.Fatalf("policy %d: Push() = %v, want an *OverflowError matching ErrFull", policy, err)
+		}
+		if overflow.Capacity != 3 || overflow.Size != 2 || overflow.Count != 2 {
+			t.Fatalf("policy %d: %+v, want capacity 3, size 2 and count 2", policy, overflow)
+		}
+		if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+			t.Fatalf("policy %d: a rejected Push changed the queue to %v", policy, got)
+		}
+		if got := q.Overflows(); got != (OverflowStats{Rejected: 2}) {
+			t.Fatalf("policy %d: Overflows() = %+v, want 2 rejected", policy, got)
+		}
+	}
+}
+
+func TestQueuePushBiggerThanCapacity(t *testing.T) {
+	q := Queue(WithCapacity(2, DropOldest))
+	if err := q.Push(1, 2, 3); !errors.Is(err, ErrFull) {
+		t.Fatalf("Push() of more elements than capacity = %v, want ErrFull", err)
+	}
+	if q.Size() != 0 || q.Capacity() != 2 {
+		t.Fatalf("size %d and capacity %d, want 0 and 2", q.Size(), q.Capacity())
+	}
+	if err := q.UnmarshalJSON([]byte(`{"kind":"int","elements":[1,2,3]}`)); !errors.Is(err, ErrFull) {
+		t.Fatalf("UnmarshalJSON() of more elements than capacity = %v, want ErrFull", err)
+	}
+}
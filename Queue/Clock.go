@@ -0,0 +1,24 @@
+package Queue
+
+import "time"
+
+// Clock is the source of time of the time based queues e.g. the delay queue
+// tests can pass their own Clock to advance the time deterministically
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After returns a channel receiving the current time once d has passed, like time.After
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock the Clock used when no Clock is passed, it reads the real time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,118 @@
+package Queue
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NewConcurrent a global function which creates, initializes and returns a queue instance
+// which is safe to use from multiple goroutines
+// it accepts the same Option values as Queue()
+func NewConcurrent(opts ...Option) *concurrentQueue {
+	cq := &concurrentQueue{
+		q: Queue(opts...),
+	}
+	cq.room = sync.NewCond(&cq.mu)
+	return cq
+}
+
+// concurrentQueue guards every queueStruct method with a read write mutex
+// compound methods like FrontAndPop and FrontsAndPops are performed under a single lock,
+// so no other goroutine can modify the queue between the front and the pop
+// the methods removing elements broadcast room to wake up the pushes waiting under the Block policy
+type concurrentQueue struct {
+	mu   sync.RWMutex
+	room *sync.Cond
+	q    *queueStruct
+}
+
+func (cq *concurrentQueue) Push(elem ...interface{}) error {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	for cq.q.mustWait(elem) {
+		cq.room.Wait()
+	}
+	return cq.q.Push(elem...)
+}
+
+func (cq *concurrentQueue) Pop() error {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.Pop()
+}
+
+func (cq *concurrentQueue) Pops(popCount int) error {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.Pops(popCount)
+}
+
+func (cq *concurrentQueue) RemoveAll() {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	cq.q.RemoveAll()
+}
+
+func (cq *concurrentQueue) Clear() {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	cq.q.Clear()
+}
+
+func (cq *concurrentQueue) Front() (interface{}, error) {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Front()
+}
+
+func (cq *concurrentQueue) Fronts(frontCount int) ([]interface{}, error) {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Fronts(frontCount)
+}
+
+func (cq *concurrentQueue) FrontAndPop() (interface{}, error) {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.FrontAndPop()
+}
+
+func (cq *concurrentQueue) FrontsAndPops(count int) ([]interface{}, error) {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.FrontsAndPops(count)
+}
+
+func (cq *concurrentQueue) Size() int {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Size()
+}
+
+func (cq *concurrentQueue) Empty() bool {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Empty()
+}
+
+func (cq *concurrentQueue) Search(elem interface{}) int {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.Search(elem)
+}
+
+func (cq *concurrentQueue) Display() {
+	fmt.Println(cq.ToSlice())
+}
+
+func (cq *concurrentQueue) ToSlice() []interface{} {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.ToSlice()
+}
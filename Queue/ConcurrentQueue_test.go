@@ -0,0 +1,131 @@
+package Queue
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentQueueParallelPushPop(t *testing.T) {
+	const goroutines, perGoroutine = 8, 500
+	cq := NewConcurrent()
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				if err := cq.Push(g*perGoroutine + i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if got := cq.Size(); got != goroutines*perGoroutine {
+		t.Fatalf("Size() = %d, want %d", got, goroutines*perGoroutine)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	record := func(elems ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, e := range elems {
+			seen[e.(int)]++
+		}
+	}
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for {
+				var err error
+				if g%2 == 0 {
+					var elem interface{}
+					if elem, err = cq.FrontAndPop(); err == nil {
+						record(elem)
+					}
+				} else {
+					var elems []interface{}
+					if elems, err = cq.FrontsAndPops(4); err == nil {
+						record(elems...)
+					} else if errors.Is(err, ErrCountExceedsSize) {
+						var elem interface{}
+						if elem, err = cq.FrontAndPop(); err == nil {
+							record(elem)
+						}
+					}
+				}
+				if errors.Is(err, ErrEmpty) {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*perGoroutine {
+		t.Fatalf("popped %d distinct elements, want %d", len(seen), goroutines*perGoroutine)
+	}
+	for elem, count := range seen {
+		if count != 1 {
+			t.Fatalf("element %d popped %d times", elem, count)
+		}
+	}
+}
+
+func TestConcurrentQueueFIFOPerProducer(t *testing.T) {
+	cq := NewConcurrent()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				_ = cq.Push([2]int{g, i})
+			}
+		}(g)
+	}
+
+	last := map[int]int{0: -1, 1: -1, 2: -1, 3: -1}
+	for received := 0; received < 4000; {
+		elems, err := cq.FrontsAndPops(1)
+		if errors.Is(err, ErrCountExceedsSize) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		pair := elems[0].([2]int)
+		if pair[1] <= last[pair[0]] {
+			t.Fatalf("producer %d: %d received after %d", pair[0], pair[1], last[pair[0]])
+		}
+		last[pair[0]] = pair[1]
+		received++
+	}
+	wg.Wait()
+}
+
+func TestConcurrentQueueBlockPolicy(t *testing.T) {
+	cq := NewConcurrent(WithCapacity(2, Block))
+	_ = cq.Push(1, 2)
+
+	pushed := make(chan error)
+	go func() { pushed <- cq.Push(3) }()
+	if _, err := cq.FrontAndPop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-pushed; err != nil {
+		t.Fatal(err)
+	}
+	if got := cq.ToSlice(); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Fatalf("ToSlice() = %v, want [2 3]", got)
+	}
+}
@@ -0,0 +1,215 @@
+package Queue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// NewDelay a global function which creates, initializes and returns a delay queue instance
+// clock is the source of time of the queue, nil means the real time
+// it accepts the same Option values as Queue()
+func NewDelay(clock Clock, opts ...Option) *delayQueue {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &delayQueue{
+		ready:   Queue(opts...).unbounded(),
+		clock:   clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// delayed an element waiting for its due time
+// seq keeps the insertion order of elements having the same due time
+type delayed struct {
+	elem interface{}
+	due  time.Time
+	seq  uint64
+}
+
+// delayHeap a min-heap of delayed elements ordered by due time, used through container/heap
+type delayHeap []*delayed
+
+func (h delayHeap) Len() int {
+	return len(h)
+}
+
+func (h delayHeap) Less(i, j int) bool {
+	if h[i].due.Equal(h[j].due) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].due.Before(h[j].due)
+}
+
+func (h delayHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *delayHeap) Push(x any) {
+	*h = append(*h, x.(*delayed))
+}
+
+func (h *delayHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return last
+}
+
+// delayQueue a queue whose elements become available only after their due time
+// the scheduled elements wait in a heap and are moved to the ready queueStruct (in due time order)
+// by the first method call after their due time
+// every scheduling closes the changed channel (and replaces it) so a waiting Take can recompute its deadline
+// it is safe to use from multiple goroutines
+type delayQueue struct {
+	mu        sync.Mutex
+	ready     *queueStruct
+	scheduled delayHeap
+	clock     Clock
+	seq       uint64
+	changed   chan struct{}
+}
+
+type delayQueueMethods interface {
+	// PushAt schedules an element to become available at the given time
+	// an element whose time has already come is available right away
+	// returns error if the data type is mismatched
+	PushAt(elem interface{}, at time.Time) error
+
+	// PushAfter schedules an element to become available after the given duration
+	PushAfter(elem interface{}, d time.Duration) error
+
+	// Front returns the earliest due element whose time has come
+	// returns ErrEmpty if no element is due yet
+	Front() (interface{}, error)
+
+	// FrontAndPop removes and returns the earliest due element whose time has come
+	// returns ErrEmpty if no element is due yet
+	FrontAndPop() (interface{}, error)
+
+	// Take removes and returns the earliest due element
+	// it waits until an element is due or ctx is done
+	Take(ctx context.Context) (interface{}, error)
+
+	// NextDue returns the due time of the earliest scheduled element which isn't available yet
+	// returns false if there is no such element
+	NextDue() (time.Time, bool)
+
+	// Size returns the number of elements, scheduled or available
+	Size() int
+
+	// Ready returns the number of available elements i.e. the elements whose time has come
+	Ready() int
+
+	// Empty checks whether the queue has no element, scheduled or available
+	Empty() bool
+
+	// private methods (for internal use only)
+
+	// promote moves the elements whose time has come to the ready queue, the lock must be held
+	promote()
+
+	// broadcast wakes up every waiting Take, the lock must be held
+	broadcast()
+}
+
+func (dq *delayQueue) PushAt(elem interface{}, at time.Time) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	// the data kind is checked when scheduling, so moving to the ready queue can't fail
+	if err := dq.ready.checkDataKind(elem); err != nil {
+		return err
+	}
+	dq.seq++
+	heap.Push(&dq.scheduled, &delayed{elem: elem, due: at, seq: dq.seq})
+	dq.broadcast()
+	return nil
+}
+
+func (dq *delayQueue) PushAfter(elem interface{}, d time.Duration) error {
+	return dq.PushAt(elem, dq.clock.Now().Add(d))
+}
+
+func (dq *delayQueue) Front() (interface{}, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.promote()
+	return dq.ready.Front()
+}
+
+func (dq *delayQueue) FrontAndPop() (interface{}, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.promote()
+	return dq.ready.FrontAndPop()
+}
+
+func (dq *delayQueue) Take(ctx context.Context) (interface{}, error) {
+	for {
+		dq.mu.Lock()
+		dq.promote()
+		if !dq.ready.Empty() {
+			elem, err := dq.ready.FrontAndPop()
+			dq.mu.Unlock()
+			return elem, err
+		}
+
+		var due <-chan time.Time
+		if len(dq.scheduled) > 0 {
+			due = dq.clock.After(dq.scheduled[0].due.Sub(dq.clock.Now()))
+		}
+		changed := dq.changed
+		dq.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		case <-due:
+		}
+	}
+}
+
+func (dq *delayQueue) NextDue() (time.Time, bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.promote()
+	if len(dq.scheduled) == 0 {
+		return time.Time{}, false
+	}
+	return dq.scheduled[0].due, true
+}
+
+func (dq *delayQueue) Size() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.ready.Size() + len(dq.scheduled)
+}
+
+func (dq *delayQueue) Ready() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.promote()
+	return dq.ready.Size()
+}
+
+func (dq *delayQueue) Empty() bool {
+	return dq.Size() == 0
+}
+
+func (dq *delayQueue) promote() {
+	now := dq.clock.Now()
+	for len(dq.scheduled) > 0 && !dq.scheduled[0].due.After(now) {
+		d := heap.Pop(&dq.scheduled).(*delayed)
+		dq.ready.Push(d.elem)
+	}
+}
+
+func (dq *delayQueue) broadcast() {
+	close(dq.changed)
+	dq.changed = make(chan struct{})
+}
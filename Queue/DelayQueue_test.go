@@ -0,0 +1,124 @@
+package Queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitForWaiters waits until a Take is waiting on the clock
+func waitForWaiters(t *testing.T, clock *fakeClock) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Take didn't wait on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDelayQueueDueOrder(t *testing.T) {
+	clock := newFakeClock()
+	dq := NewDelay(clock)
+	_ = dq.PushAfter("c", 3*time.Second)
+	_ = dq.PushAfter("a", time.Second)
+	_ = dq.PushAfter("b1", 2*time.Second)
+	_ = dq.PushAfter("b2", 2*time.Second)
+
+	if _, err := dq.Front(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Front() before any due time = %v, want ErrEmpty", err)
+	}
+	if due, ok := dq.NextDue(); !ok || !due.Equal(clock.Now().Add(time.Second)) {
+		t.Fatalf("NextDue() = %v, %t, want the due time of a", due, ok)
+	}
+
+	clock.Advance(2 * time.Second)
+	if dq.Ready() != 3 || dq.Size() != 4 {
+		t.Fatalf("Ready() = %d and Size() = %d, want 3 and 4", dq.Ready(), dq.Size())
+	}
+	// the elements having the same due time keep their insertion order
+	for _, want := range []string{"a", "b1", "b2"} {
+		if elem, err := dq.FrontAndPop(); err != nil || elem != want {
+			t.Fatalf("FrontAndPop() = %v, %v, want %s", elem, err, want)
+		}
+	}
+	if _, err := dq.FrontAndPop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("FrontAndPop() before c is due = %v, want ErrEmpty", err)
+	}
+
+	// an element whose time has already come is available right away
+	_ = dq.PushAt("late", clock.Now().Add(-time.Hour))
+	if elem, _ := dq.Front(); elem != "late" {
+		t.Fatalf("Front() = %v, want late", elem)
+	}
+	var mismatch *KindMismatchError
+	if err := dq.PushAfter(1, 0); !errors.As(err, &mismatch) {
+		t.Fatalf("PushAfter(1) = %v, want a *KindMismatchError", err)
+	}
+}
+
+func TestDelayQueueTakeWaitsForDueTime(t *testing.T) {
+	clock := newFakeClock()
+	dq := NewDelay(clock)
+	_ = dq.PushAfter(1, time.Minute)
+
+	var taken interface{}
+	done := result(func() (err error) {
+		taken, err = dq.Take(context.Background())
+		return err
+	})
+	waitForWaiters(t, clock)
+	clock.Advance(time.Minute - time.Second)
+	mustWait(t, done)
+
+	clock.Advance(time.Second)
+	if err := mustReturn(t, done); err != nil || taken != 1 {
+		t.Fatalf("Take() = %v, %v, want 1, nil", taken, err)
+	}
+}
+
+func TestDelayQueueTakeWakesOnEarlierPush(t *testing.T) {
+	clock := newFakeClock()
+	dq := NewDelay(clock)
+	_ = dq.PushAfter("later", time.Hour)
+
+	var taken interface{}
+	done := result(func() (err error) {
+		taken, err = dq.Take(context.Background())
+		return err
+	})
+	waitForWaiters(t, clock)
+	mustWait(t, done)
+
+	// the waiting Take recomputes its deadline, no time has to pass
+	_ = dq.PushAfter("now", 0)
+	if err := mustReturn(t, done); err != nil || taken != "now" {
+		t.Fatalf("Take() = %v, %v, want now, nil", taken, err)
+	}
+	if dq.Size() != 1 {
+		t.Fatalf("Size() = %d, want 1", dq.Size())
+	}
+}
+
+func TestDelayQueueTakeCancel(t *testing.T) {
+	dq := NewDelay(newFakeClock())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := result(func() error { _, err := dq.Take(ctx); return err })
+	mustWait(t, done)
+	cancel()
+	if err := mustReturn(t, done); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take() = %v, want context.Canceled", err)
+	}
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	dq := NewDelay(nil)
+	_ = dq.PushAfter("x", time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if elem, err := dq.Take(ctx); err != nil || elem != "x" {
+		t.Fatalf("Take() = %v, %v, want x, nil", elem, err)
+	}
+}
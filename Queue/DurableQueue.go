@@ -0,0 +1,435 @@
+package Queue
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"time"
+)
+
+// Open a global function which opens the durable queue stored in the directory dir and returns it
+// the directory is created if it doesn't exist, otherwise its write-ahead log is replayed
+// so the queue has the same elements and data kind it had before the process stopped
+// returns ErrLocked if the directory is already opened by another queue (of any process)
+func Open(dir string, opts ...DurableOption) (*durableQueue, error) {
+	config := durableConfig{
+		syncPolicy:   SyncAlways,
+		syncInterval: time.Second,
+		segmentSize:  defaultSegmentSize,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	log, records, err := openWAL(dir, config.syncPolicy, config.syncInterval, config.segmentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	dq := &durableQueue{
+		q:   Queue(),
+		log: log,
+	}
+	if err := dq.replay(records); err != nil {
+		log.close()
+		return nil, err
+	}
+	log.checkpoint = dq.checkpoint
+	return dq, nil
+}
+
+// DurableOption configures a durable queue opened by Open()
+type DurableOption func(*durableConfig)
+
+// durableConfig where the options of a durable queue are stored
+type durableConfig struct {
+	syncPolicy   SyncPolicy
+	syncInterval time.Duration
+	segmentSize  int64
+}
+
+// WithSyncPolicy sets when the log is flushed to stable storage, SyncAlways is used by default
+// interval is only used by SyncInterval, one second is used if it isn't positive
+func WithSyncPolicy(policy SyncPolicy, interval time.Duration) DurableOption {
+	return func(c *durableConfig) {
+		c.syncPolicy = policy
+		if interval > 0 {
+			c.syncInterval = interval
+		}
+	}
+}
+
+// WithSegmentSize sets the size (in bytes) after which the log continues in a new segment file, 64 MiB by default
+// smaller segments are deleted sooner once their elements are popped
+func WithSegmentSize(size int64) DurableOption {
+	return func(c *durableConfig) {
+		if size > 0 {
+			c.segmentSize = size
+		}
+	}
+}
+
+// durableQueue a queueStruct whose every change is appended to a write-ahead log before it is applied
+// every pushed element gets the next sequence number and a pop records the sequence number of the new Front(),
+// so replaying the log gives the exact elements and a fully popped segment can be deleted
+// like queueStruct, it is not safe to use from multiple goroutines without synchronization
+type durableQueue struct {
+	q    *queueStruct
+	log  *wal
+	head uint64
+}
+
+type durableQueueMethods interface {
+	// Push, Pop, Pops, Front, Fronts, FrontAndPop, FrontsAndPops, Size, Empty, Search, Display, ToSlice, View,
+	// MarshalJSON, UnmarshalJSON, MarshalBinary, UnmarshalBinary, GobEncode, GobDecode, All, Drain, Capacity and Overflows
+	// work like the methods of queueStruct, every change is written to the log first
+	// a change which can't be written returns the error and doesn't change the queue
+	// only the data kinds supported by the binary format of internal/Codec can be pushed,
+	// a pushed element is read back as the builtin type of its data kind
+	// the elements pushed by a single Push or UnmarshalJSON/UnmarshalBinary are recovered all together or not at all
+	Push(elem ...interface{}) error
+	Pop() error
+	Pops(popCount int) error
+	Front() (interface{}, error)
+	Fronts(frontCount int) ([]interface{}, error)
+	FrontAndPop() (interface{}, error)
+	FrontsAndPops(count int) ([]interface{}, error)
+	Size() int
+	Empty() bool
+	Search(elem interface{}) int
+	Display()
+	ToSlice() []interface{}
+	View() View[interface{}]
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(data []byte) error
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+	GobEncode() ([]byte, error)
+	GobDecode(data []byte) error
+	All() iter.Seq2[int, interface{}]
+	Drain() iter.Seq[interface{}]
+	Capacity() int
+	Overflows() OverflowStats
+
+	// RemoveAll removes all elements from the queue like queueStruct.RemoveAll
+	// returns error if the change can't be written to the log, the queue is then unchanged
+	RemoveAll() error
+
+	// Clear removes all elements and the data kind of the queue like queueStruct.Clear
+	// returns error if the change can't be written to the log, the queue is then unchanged
+	Clear() error
+
+	// Sync flushes the log to stable storage regardless of the sync policy
+	Sync() error
+
+	// Close flushes and closes the log, the queue can't be used afterwards
+	// returns ErrClosed if the queue is already closed
+	Close() error
+
+	// private methods (for internal use only)
+
+	// replay rebuilds the queue from the records of the log
+	replay(records []walRecord) error
+
+	// advance pops count elements after writing a pop record and deletes the fully popped segments
+	advance(count int) error
+
+	// tail returns the sequence number of the next pushed element
+	tail() uint64
+
+	// checkpoint returns a pop record payload (popping nothing) holding the head and the data kind of the queue
+	checkpoint() []byte
+}
+
+func (dq *durableQueue) Push(elem ...interface{}) error {
+	if len(elem) == 0 {
+		return nil
+	}
+
+	probe := Queue()
+	probe.queueDataKind = dq.q.queueDataKind
+	for _, e := range elem {
+		if err := probe.checkDataKind(e); err != nil {
+			return err
+		}
+	}
+
+	tail := dq.tail()
+	payloads := make([][]byte, len(elem))
+	for i, e := range elem {
+		payload, err := pushPayload(tail+uint64(i), e)
+		if err != nil {
+			return err
+		}
+		payloads[i] = payload
+	}
+	if err := dq.log.append(payloads, true, tail+uint64(len(elem))-1); err != nil {
+		return err
+	}
+	return dq.q.Push(elem...)
+}
+
+func (dq *durableQueue) Pop() error {
+	if dq.Empty() {
+		return ErrEmpty
+	}
+	return dq.advance(1)
+}
+
+func (dq *durableQueue) Pops(popCount int) error {
+	if err := checkCount("pop", popCount, dq.Size()); err != nil {
+		return err
+	}
+	return dq.advance(popCount)
+}
+
+func (dq *durableQueue) RemoveAll() error {
+	return dq.advance(dq.Size())
+}
+
+func (dq *durableQueue) Clear() error {
+	tail := dq.tail()
+	if err := dq.log.append([][]byte{clearPayload(tail)}, false, 0); err != nil {
+		return err
+	}
+	dq.q.Clear()
+	dq.head = tail
+	return dq.log.compact(dq.head)
+}
+
+func (dq *durableQueue) Front() (interface{}, error) {
+	return dq.q.Front()
+}
+
+func (dq *durableQueue) Fronts(frontCount int) ([]interface{}, error) {
+	return dq.q.Fronts(frontCount)
+}
+
+func (dq *durableQueue) FrontAndPop() (interface{}, error) {
+	elem, err := dq.Front()
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.Pop(); err != nil {
+		return nil, err
+	}
+	return elem, nil
+}
+
+func (dq *durableQueue) FrontsAndPops(count int) ([]interface{}, error) {
+	elemSlice, err := dq.Fronts(count)
+	if err != nil {
+		return nil, err
+	}
+	if err := dq.Pops(count); err != nil {
+		return nil, err
+	}
+	return elemSlice, nil
+}
+
+func (dq *durableQueue) Size() int {
+	return dq.q.Size()
+}
+
+func (dq *durableQueue) Empty() bool {
+	return dq.q.Empty()
+}
+
+func (dq *durableQueue) Search(elem interface{}) int {
+	return dq.q.Search(elem)
+}
+
+func (dq *durableQueue) Display() {
+	fmt.Println(dq.ToSlice())
+}
+
+func (dq *durableQueue) ToSlice() []interface{} {
+	return dq.q.ToSlice()
+}
+
+func (dq *durableQueue) View() View[interface{}] {
+	return dq.q.View()
+}
+
+func (dq *durableQueue) MarshalJSON() ([]byte, error) {
+	return dq.q.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the elements and the data kind of the queue with the decoded ones
+// and writes the replacement to the log as a Clear followed by the pushes in a single batch record
+func (dq *durableQueue) UnmarshalJSON(data []byte) error {
+	decoded := Queue()
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return dq.replace(decoded)
+}
+
+func (dq *durableQueue) MarshalBinary() ([]byte, error) {
+	return dq.q.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the elements and the data kind of the queue with the decoded ones
+// and writes the replacement to the log as a Clear followed by the pushes in a single batch record
+func (dq *durableQueue) UnmarshalBinary(data []byte) error {
+	decoded := Queue()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	return dq.replace(decoded)
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (dq *durableQueue) GobEncode() ([]byte, error) {
+	return dq.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (dq *durableQueue) GobDecode(data []byte) error {
+	return dq.UnmarshalBinary(data)
+}
+
+func (dq *durableQueue) All() iter.Seq2[int, interface{}] {
+	return dq.q.All()
+}
+
+func (dq *durableQueue) Drain() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for {
+			elem, err := dq.FrontAndPop()
+			if err != nil || !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Capacity returns 0 as a durable queue is unbounded
+func (dq *durableQueue) Capacity() int {
+	return dq.q.Capacity()
+}
+
+func (dq *durableQueue) Overflows() OverflowStats {
+	return dq.q.Overflows()
+}
+
+func (dq *durableQueue) Sync() error {
+	return dq.log.flush()
+}
+
+func (dq *durableQueue) Close() error {
+	return dq.log.close()
+}
+
+func (dq *durableQueue) replay(records []walRecord) error {
+	head := uint64(0)
+	kind := reflect.Invalid
+	pushes := make([]walRecord, 0)
+	for _, record := range records {
+		switch record.op {
+		case walPush:
+			pushes = append(pushes, record)
+			kind = record.kind
+		case walPop, walClear:
+			if record.seq > head {
+				head = record.seq
+			}
+			kind = record.kind
+		}
+	}
+
+	elems := make([]interface{}, 0)
+	for _, record := range pushes {
+		if record.seq < head {
+			continue
+		}
+		if len(elems) == 0 {
+			// the pop records of compacted segments are gone, the first element left is the Front()
+			head = record.seq
+		}
+		if record.seq != head+uint64(len(elems)) {
+			return fmt.Errorf("log has a gap before the element having sequence number %d", record.seq)
+		}
+		elems = append(elems, record.elem)
+	}
+
+	dq.q.queueDataKind = kind
+	if err := dq.q.Push(elems...); err != nil {
+		return err
+	}
+	dq.head = head
+	return nil
+}
+
+func (dq *durableQueue) advance(count int) error {
+	if count <= 0 {
+		return nil
+	}
+
+	head := dq.head + uint64(count)
+	if err := dq.log.append([][]byte{popPayload(head, dq.q.queueDataKind)}, false, 0); err != nil {
+		return err
+	}
+	if err := dq.q.Pops(count); err != nil {
+		return err
+	}
+	dq.head = head
+	return dq.log.compact(head)
+}
+
+// replace writes a Clear, the pushes of the decoded elements and a pop record (popping nothing)
+// keeping the decoded data kind as a single batch record, then replaces the queue with decoded
+func (dq *durableQueue) replace(decoded *queueStruct) error {
+	tail := dq.tail()
+	elems := decoded.ToSlice()
+	payloads := [][]byte{clearPayload(tail)}
+	for i, elem := range elems {
+		payload, err := pushPayload(tail+uint64(i), elem)
+		if err != nil {
+			return err
+		}
+		payloads = append(payloads, payload)
+	}
+	payloads = append(payloads, popPayload(tail, decoded.queueDataKind))
+
+	newTail := tail + uint64(len(elems))
+	if err := dq.log.append(payloads, len(elems) != 0, newTail-1); err != nil {
+		return err
+	}
+	dq.q.queue = decoded.queue
+	dq.q.queueDataKind = decoded.queueDataKind
+	dq.head = tail
+	return dq.log.compact(tail)
+}
+
+func (dq *durableQueue) tail() uint64 {
+	return dq.head + uint64(dq.Size())
+}
+
+func (dq *durableQueue) checkpoint() []byte {
+	return popPayload(dq.head, dq.q.queueDataKind)
+}
+
+func (dq *durableQueue) checkDataKind(val interface{}) error {
+	return dq.q.checkDataKind(val)
+}
+
+func (dq *durableQueue) isEqual(a, b interface{}) bool {
+	return dq.q.isEqual(a, b)
+}
+
+func (dq *durableQueue) makeRoom(count int) (bool, error) {
+	return dq.q.makeRoom(count)
+}
+
+func (dq *durableQueue) checkCapacity(count int) error {
+	return dq.q.checkCapacity(count)
+}
+
+func (dq *durableQueue) mustWait(elem []interface{}) bool {
+	return dq.q.mustWait(elem)
+}
+
+func (dq *durableQueue) unbounded() *queueStruct {
+	return dq.q.unbounded()
+}
@@ -0,0 +1,373 @@
+package Queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// lastSegment returns the path of the active log segment of dir
+func lastSegment(t *testing.T, dir string) string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+walSuffix))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no log segment in %s: %v", dir, err)
+	}
+	return files[len(files)-1]
+}
+
+// segmentSize returns the size of the file
+func segmentSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func mustOpen(t *testing.T, dir string, opts ...DurableOption) *durableQueue {
+	t.Helper()
+	dq, err := Open(dir, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = dq.Close() })
+	return dq
+}
+
+// crash drops the queue like a killed process: nothing is flushed, the files and the directory lock are released
+func crash(dq *durableQueue) {
+	dq.log.mu.Lock()
+	defer dq.log.mu.Unlock()
+	dq.log.closed = true
+	close(dq.log.done)
+	dq.log.file.Close()
+	dq.log.lock.Close()
+}
+
+func TestDurableQueueRecovery(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir, WithSegmentSize(64))
+	for i := 0; i < 100; i++ {
+		if err := dq.Push(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dq.Pops(60); err != nil {
+		t.Fatal(err)
+	}
+	// no Close, the process crashes with SyncAlways
+	crash(dq)
+	reopened := mustOpen(t, dir, WithSegmentSize(64))
+	if got := reopened.ToSlice(); len(got) != 40 || got[0] != 60 || got[39] != 99 {
+		t.Fatalf("recovered %v, want 60..99", got)
+	}
+
+	if err := reopened.RemoveAll(); err != nil {
+		t.Fatal(err)
+	}
+	_ = reopened.Close()
+	reopened = mustOpen(t, dir)
+	if reopened.Size() != 0 || reopened.q.queueDataKind != reflect.Int {
+		t.Fatalf("after RemoveAll: size %d, kind %v", reopened.Size(), reopened.q.queueDataKind)
+	}
+	if err := reopened.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	_ = reopened.Close()
+	reopened = mustOpen(t, dir)
+	if err := reopened.Push("a"); err != nil {
+		t.Fatalf("Push after Clear: %v", err)
+	}
+}
+
+func TestDurableQueueCompaction(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir, WithSegmentSize(64))
+	for i := 0; i < 200; i++ {
+		_ = dq.Push(i)
+	}
+	before, _ := filepath.Glob(filepath.Join(dir, "*"+walSuffix))
+	if err := dq.Pops(190); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := filepath.Glob(filepath.Join(dir, "*"+walSuffix))
+	if len(after) >= len(before) {
+		t.Fatalf("%d segments before and %d after popping, want fewer", len(before), len(after))
+	}
+
+	crash(dq)
+	reopened := mustOpen(t, dir, WithSegmentSize(64))
+	if got := reopened.ToSlice(); len(got) != 10 || got[0] != 190 {
+		t.Fatalf("recovered %v, want 190..199", got)
+	}
+}
+
+func TestDurableQueueTornTail(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir)
+	_ = dq.Push(1, 2)
+	intact := segmentSize(t, lastSegment(t, dir))
+	_ = dq.Push(3, 4, 5)
+	_ = dq.Close()
+
+	// every cut of the last batch record is a torn write, the batch is dropped as a whole
+	path := lastSegment(t, dir)
+	full, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cut := intact + 1; cut < int64(len(full)); cut++ {
+		if err := os.WriteFile(path, full[:cut], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		reopened, err := Open(dir)
+		if err != nil {
+			t.Fatalf("cut at %d: %v", cut, err)
+		}
+		if got := reopened.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+			t.Fatalf("cut at %d: recovered %v, want [1 2]", cut, got)
+		}
+		if size := segmentSize(t, path); size != intact {
+			t.Fatalf("cut at %d: segment size %d after recovery, want %d", cut, size, intact)
+		}
+		_ = reopened.Close()
+	}
+
+	if err := os.WriteFile(path, full[:intact+1], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reopened := mustOpen(t, dir)
+	if err := reopened.Push(6); err != nil {
+		t.Fatal(err)
+	}
+	_ = reopened.Close()
+	reopened = mustOpen(t, dir)
+	if got := reopened.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 6}) {
+		t.Fatalf("recovered %v, want [1 2 6]", got)
+	}
+}
+
+func TestDurableQueueTornReplacement(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir)
+	_ = dq.Push(1, 2, 3)
+	intact := segmentSize(t, lastSegment(t, dir))
+	if err := dq.UnmarshalJSON([]byte(`{"kind":"string","elements":["a","b","c"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	_ = dq.Close()
+
+	reopened := mustOpen(t, dir)
+	if got := reopened.ToSlice(); !reflect.DeepEqual(got, []interface{}{"a", "b", "c"}) {
+		t.Fatalf("recovered %v, want [a b c]", got)
+	}
+	_ = reopened.Close()
+
+	// a replacement torn anywhere keeps the old elements, never the clear with a part of the new ones
+	path := lastSegment(t, dir)
+	full, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cut := int64(len(full)) - 1; cut > intact; cut-- {
+		if err := os.WriteFile(path, full[:cut], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		reopened, err := Open(dir)
+		if err != nil {
+			t.Fatalf("cut at %d: %v", cut, err)
+		}
+		if got := reopened.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+			t.Fatalf("cut at %d: recovered %v, want [1 2 3]", cut, got)
+		}
+		_ = reopened.Close()
+	}
+}
+
+func TestDurableQueueCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(data []byte) []byte
+	}{
+		{"checksum of a middle record", func(data []byte) []byte {
+			// the payload of the first record, the second one is still valid
+			data[walHeaderSize] ^= 0xff
+			return data
+		}},
+		{"checksum of the last record", func(data []byte) []byte {
+			data[len(data)-1] ^= 0xff
+			return data
+		}},
+		{"unknown operation", func(data []byte) []byte {
+			payload := []byte{walBatch + 1, 0}
+			record := make([]byte, walHeaderSize, walHeaderSize+len(payload))
+			putRecordHeader(record, payload)
+			return append(data, append(record, payload...)...)
+		}},
+		{"invalid length", func(data []byte) []byte {
+			return append(data, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dq := mustOpen(t, dir)
+			_ = dq.Push(1)
+			_ = dq.Push(2, 3)
+			_ = dq.Close()
+
+			path := lastSegment(t, dir)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = tt.corrupt(data)
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Open(dir); !errors.Is(err, ErrCorruptedLog) {
+				t.Fatalf("Open() error = %v, want ErrCorruptedLog", err)
+			}
+			if after, _ := os.ReadFile(path); len(after) != len(data) {
+				t.Fatalf("corrupted segment was truncated from %d to %d bytes", len(data), len(after))
+			}
+		})
+	}
+}
+
+func TestDurableQueueSyncInterval(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir, WithSyncPolicy(SyncInterval, time.Millisecond))
+	for i := 0; i < 10; i++ {
+		_ = dq.Push(i)
+		time.Sleep(time.Millisecond)
+	}
+	if err := dq.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dq.Close(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("second Close() = %v, want ErrClosed", err)
+	}
+	if err := dq.Push(1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Push() after Close() = %v, want ErrClosed", err)
+	}
+	if err := dq.RemoveAll(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("RemoveAll() after Close() = %v, want ErrClosed", err)
+	}
+	if dq.Size() != 10 {
+		t.Fatalf("RemoveAll() after Close() changed the queue to %d elements", dq.Size())
+	}
+
+	reopened := mustOpen(t, dir)
+	if reopened.Size() != 10 {
+		t.Fatalf("recovered %d elements, want 10", reopened.Size())
+	}
+}
+
+func TestDurableQueueRejectsMismatchedKind(t *testing.T) {
+	dq := mustOpen(t, t.TempDir())
+	_ = dq.Push(1)
+	var mismatch *KindMismatchError
+	if err := dq.Push(2, "x"); !errors.As(err, &mismatch) {
+		t.Fatalf("Push() error = %v, want *KindMismatchError", err)
+	}
+	if dq.Size() != 1 {
+		t.Fatalf("a rejected Push changed the queue to %d elements", dq.Size())
+	}
+}
+
+func TestDurableQueueZeroFilledTail(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir)
+	_ = dq.Push(1, 2)
+	_ = dq.Close()
+
+	// the file size was updated by the crash but not its content
+	path := lastSegment(t, dir)
+	intact := segmentSize(t, path)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.Write(make([]byte, 3*walHeaderSize))
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := mustOpen(t, dir)
+	if got := reopened.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatalf("recovered %v, want [1 2]", got)
+	}
+	if size := segmentSize(t, path); size != intact {
+		t.Fatalf("segment size %d after recovery, want %d", size, intact)
+	}
+}
+
+func TestDurableQueueKeepsKindAfterCompaction(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir, WithSegmentSize(64), WithSyncPolicy(SyncNever, 0))
+	for i := 0; i < 50; i++ {
+		_ = dq.Push(i)
+	}
+	if err := dq.RemoveAll(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		_ = dq.Push(i)
+		_ = dq.Pop()
+	}
+	crash(dq)
+
+	// every segment before the last one is compacted away, and the crash lost everything but its checkpoint
+	segments, _ := filepath.Glob(filepath.Join(dir, "*"+walSuffix))
+	if len(segments) != 1 {
+		t.Fatalf("%d segments left after popping everything, want 1", len(segments))
+	}
+	file, err := os.Open(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, size, err := readWALRecord(file, make([]byte, walHeaderSize))
+	file.Close()
+	if err != nil || len(records) != 1 || records[0].op != walPop || records[0].kind != reflect.Int {
+		t.Fatalf("first record of the segment is %v (%v), want an int pop checkpoint", records, err)
+	}
+	if err := os.Truncate(segments[0], size); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := mustOpen(t, dir)
+	if reopened.Size() != 0 || reopened.q.queueDataKind != reflect.Int {
+		t.Fatalf("recovered size %d and kind %v, want an empty int queue", reopened.Size(), reopened.q.queueDataKind)
+	}
+	var mismatch *KindMismatchError
+	if err := reopened.Push("a"); !errors.As(err, &mismatch) {
+		t.Fatalf("Push(\"a\") = %v, want *KindMismatchError", err)
+	}
+}
+
+func TestDurableQueueLock(t *testing.T) {
+	dir := t.TempDir()
+	dq := mustOpen(t, dir)
+	if _, err := Open(dir); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second Open() = %v, want ErrLocked", err)
+	}
+	if err := dq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened := mustOpen(t, dir)
+	if err := reopened.Push(1); err != nil {
+		t.Fatal(err)
+	}
+}
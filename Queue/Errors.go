@@ -0,0 +1,125 @@
+package Queue
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrEmpty is returned when an element is requested from or popped from an empty queue
+	ErrEmpty = errors.New("invalid operation as queue is empty")
+
+	// ErrCountExceedsSize is matched (with errors.Is) by the errors returned when
+	// the requested element count is bigger than the size of the queue
+	ErrCountExceedsSize = errors.New("invalid operation as count is greater than the queue size")
+
+	// ErrNegativeCount is matched (with errors.Is) by the errors returned when the requested element count is negative
+	ErrNegativeCount = errors.New("invalid operation as count is negative")
+
+	// ErrClosed is returned by the blocking and durable queue methods once the queue is closed
+	ErrClosed = errors.New("invalid operation as queue is closed")
+
+	// ErrFull is returned by TryPut when the queue is at its capacity
+	// and matched (with errors.Is) by the errors returned when the pushed elements don't fit in a queue created WithCapacity
+	ErrFull = errors.New("invalid operation as queue is full")
+
+	// ErrInvalidReceipt is returned by Ack and Nack of the reliable queue when the receipt
+	// is already used or its visibility timeout has passed
+	ErrInvalidReceipt = errors.New("invalid operation as receipt is unknown or expired")
+
+	// ErrCorruptedLog is matched (with errors.Is) by the error returned by Open when a record of the log
+	// can't be read for any other reason than a write torn by a crash at the end of the log
+	ErrCorruptedLog = errors.New("invalid log as a record is corrupted")
+
+	// ErrLocked is returned by Open when the directory is already opened by another durable queue
+	ErrLocked = errors.New("invalid operation as directory is locked by another queue")
+)
+
+// KindMismatchError is returned when an element's data kind differs from the data kind of the queue
+type KindMismatchError struct {
+	Expected reflect.Kind
+	Got      reflect.Kind
+}
+
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("invalid value type: expected %v, got %v", e.Expected, e.Got)
+}
+
+// UnsupportedKindError is returned when an element's data kind can't be stored in a queue
+// see Codec.UnsupportedKinds and the options which lift this restriction
+type UnsupportedKindError struct {
+	Kind reflect.Kind
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return fmt.Sprintf("%v is not supported type for queue", e.Kind)
+}
+
+// ViewIndexError is the panic value of View.At when Index is out of the range [0, Len)
+type ViewIndexError struct {
+	Index int
+	Len   int
+}
+
+func (e *ViewIndexError) Error() string {
+	return fmt.Sprintf("Queue: view index %d out of range [0, %d)", e.Index, e.Len)
+}
+
+// OverflowError is returned when Count pushed elements don't fit in a queue having Size elements and the given Capacity
+// it matches ErrFull
+type OverflowError struct {
+	Capacity int
+	Size     int
+	Count    int
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("invalid operation as %d more elements exceed the queue capacity(%d) with size(%d)", e.Count, e.Capacity, e.Size)
+}
+
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrFull
+}
+
+// countExceedsSizeError describes which count (pop, top, front, ...) is bigger than the queue size
+// it matches ErrCountExceedsSize
+type countExceedsSizeError struct {
+	op    string
+	count int
+	size  int
+}
+
+func (e *countExceedsSizeError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is greater than the queue size(%d)", e.op, e.count, e.size)
+}
+
+func (e *countExceedsSizeError) Is(target error) bool {
+	return target == ErrCountExceedsSize
+}
+
+// negativeCountError describes which count (pop, top, front, ...) is negative
+// it matches ErrNegativeCount
+type negativeCountError struct {
+	op    string
+	count int
+}
+
+func (e *negativeCountError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is negative", e.op, e.count)
+}
+
+func (e *negativeCountError) Is(target error) bool {
+	return target == ErrNegativeCount
+}
+
+// checkCount returns error if the count of the op is negative or bigger than the size of the queue
+func checkCount(op string, count, size int) error {
+	if count < 0 {
+		return &negativeCountError{op: op, count: count}
+	}
+	if count > size {
+		return &countExceedsSizeError{op: op, count: count, size: size}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package Queue
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Ring"
+)
+
+// New a global function which creates, initializes and returns a type safe queue instance
+// elements are compared with == (used by Search)
+func New[T comparable]() *genericQueue[T] {
+	return NewWithEqual[T](func(a, b T) bool { return a == b })
+}
+
+// NewWithEqual creates, initializes and returns a type safe queue instance
+// for element types which are not comparable with ==
+// equal is used by Search to compare two elements
+func NewWithEqual[T any](equal func(a, b T) bool) *genericQueue[T] {
+	return &genericQueue[T]{
+		queue: Ring.New[T](),
+		equal: equal,
+	}
+}
+
+// genericQueue where type safe queue data are stored
+// the data are kept in a circular buffer, so Push, Pop and Front are amortized O(1)
+type genericQueue[T any] struct {
+	queue *Ring.Ring[T]
+	equal func(a, b T) bool
+}
+
+// genericQueueMethods stores interface declaration of all genericQueue methods
+type genericQueueMethods[T any] interface {
+	// Push adds one or more elements to an existing queue
+	Push(elem ...T)
+
+	// Pop removes the earliest inserted element from the caller queue
+	Pop() error
+
+	// Pops can pop multiple elements from the front of the queue
+	// returns error if the popCount number is negative or bigger than the size of the queue
+	Pops(popCount int) error
+
+	// RemoveAll removes all elements from the caller queue
+	RemoveAll()
+
+	// Front returns the front element i.e. first inserted element from the queue
+	// and error (if queue is empty)
+	Front() (T, error)
+
+	// Fronts returns the earliest inserted elements equal to frontCount (stored in a new slice)
+	// and error (if any)
+	Fronts(frontCount int) ([]T, error)
+
+	// FrontAndPop retrieves the Front() element from the queue
+	// returns the front element and also Pop() from the queue
+	// also returns error (if any)
+	FrontAndPop() (T, error)
+
+	// FrontsAndPops returns the earliest inserted elements equal to count (stored in a slice)
+	// and also pop those elements from the queue
+	// and error (if any)
+	FrontsAndPops(count int) ([]T, error)
+
+	// Size returns the size of an existing queue
+	Size() int
+
+	// Empty checks whether the queue is empty or not
+	// returns true if empty else false
+	Empty() bool
+
+	// Search finds the parametric element in the queue
+	// if the element is found then returns the position from the Front else -1 (not found)
+	// N.B. Front() is taken as position 1
+	Search(elem T) int
+
+	// Display prints the queue value as slice on console screen
+	// the left most data is the first inserted value
+	Display()
+
+	// ToSlice returns a copy of the queue as slice
+	// changing the returned slice doesn't change the queue, use View to read the queue without copying
+	ToSlice() []T
+
+	// View returns a read-only view of the queue which doesn't copy the elements
+	View() View[T]
+
+	// All returns an iterator over the queue from the Front() to the back
+	// it yields the position of every element (Front() is taken as position 1, like Search) and the element
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so pushing to or popping from the queue inside the loop doesn't change what is yielded
+	All() iter.Seq2[int, T]
+
+	// Drain returns an iterator which pops and yields the Front() element until the queue is empty
+	// or the loop stops, elements pushed inside the loop are drained too
+	Drain() iter.Seq[T]
+}
+
+func (q *genericQueue[T]) Push(elem ...T) {
+	for _, e := range elem {
+		q.queue.PushBack(e)
+	}
+}
+
+func (q *genericQueue[T]) Pop() error {
+	if q.Empty() {
+		return ErrEmpty
+	}
+
+	q.queue.PopFront()
+	return nil
+}
+
+func (q *genericQueue[T]) Pops(popCount int) error {
+	if err := checkCount("pop", popCount, q.Size()); err != nil {
+		return err
+	}
+
+	q.queue.PopFronts(popCount)
+	return nil
+}
+
+func (q *genericQueue[T]) RemoveAll() {
+	q.queue = Ring.New[T]()
+}
+
+func (q *genericQueue[T]) Front() (T, error) {
+	if q.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return q.queue.At(0), nil
+}
+
+func (q *genericQueue[T]) Fronts(frontCount int) ([]T, error) {
+	if err := checkCount("front", frontCount, q.Size()); err != nil {
+		return nil, err
+	}
+
+	return q.queue.Slice(0, frontCount), nil
+}
+
+func (q *genericQueue[T]) FrontAndPop() (T, error) {
+	elem, err := q.Front()
+	if err != nil {
+		return elem, err
+	}
+	if err := q.Pop(); err != nil {
+		return elem, err
+	}
+	return elem, nil
+}
+
+func (q *genericQueue[T]) FrontsAndPops(count int) ([]T, error) {
+	elemSlice, err := q.Fronts(count)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Pops(count); err != nil {
+		return nil, err
+	}
+	return elemSlice, nil
+}
+
+func (q *genericQueue[T]) Size() int {
+	return q.queue.Len()
+}
+
+func (q *genericQueue[T]) Empty() bool {
+	return q.Size() == 0
+}
+
+func (q *genericQueue[T]) Search(elem T) int {
+	queueSize := q.Size()
+	for i := 0; i < queueSize; i++ {
+		if q.equal(q.queue.At(i), elem) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (q *genericQueue[T]) Display() {
+	fmt.Println(q.ToSlice())
+}
+
+func (q *genericQueue[T]) ToSlice() []T {
+	return q.queue.Slice(0, q.Size())
+}
@@ -0,0 +1,76 @@
+package Queue
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGenericQueueOrder(t *testing.T) {
+	q := New[string]()
+	q.Push("a", "b", "c")
+	q.Push("d")
+	if front, err := q.Front(); err != nil || front != "a" {
+		t.Fatalf("Front() = %q, %v, want a", front, err)
+	}
+	fronts, err := q.Fronts(2)
+	if err != nil || !reflect.DeepEqual(fronts, []string{"a", "b"}) {
+		t.Fatalf("Fronts(2) = %v, %v, want [a b]", fronts, err)
+	}
+	if front, err := q.FrontAndPop(); err != nil || front != "a" {
+		t.Fatalf("FrontAndPop() = %q, %v, want a", front, err)
+	}
+	popped, err := q.FrontsAndPops(2)
+	if err != nil || !reflect.DeepEqual(popped, []string{"b", "c"}) {
+		t.Fatalf("FrontsAndPops(2) = %v, %v, want [b c]", popped, err)
+	}
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []string{"d"}) || q.Size() != 1 {
+		t.Fatalf("queue %v of size %d, want [d]", got, q.Size())
+	}
+	if err := q.Pop(); err != nil || !q.Empty() {
+		t.Fatalf("Pop() = %v, queue empty %v", err, q.Empty())
+	}
+}
+
+func TestGenericQueueSearch(t *testing.T) {
+	q := New[int]()
+	q.Push(5, 6, 7, 6)
+	if got := q.Search(6); got != 2 {
+		t.Fatalf("Search(6) = %d, want 2", got)
+	}
+	if got := q.Search(8); got != -1 {
+		t.Fatalf("Search(8) = %d, want -1", got)
+	}
+
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	records := NewWithEqual(func(a, b record) bool { return a.ID == b.ID })
+	records.Push(record{1, []string{"a"}}, record{2, nil})
+	if got := records.Search(record{ID: 2}); got != 2 {
+		t.Fatalf("Search() with the equal function = %d, want 2", got)
+	}
+}
+
+func TestGenericQueueEmpty(t *testing.T) {
+	q := New[int]()
+	if front, err := q.Front(); !errors.Is(err, ErrEmpty) || front != 0 {
+		t.Fatalf("Front() = %d, %v, want 0, ErrEmpty", front, err)
+	}
+	if _, err := q.FrontAndPop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("FrontAndPop() = %v, want ErrEmpty", err)
+	}
+	if err := q.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Pop() = %v, want ErrEmpty", err)
+	}
+	if _, err := q.Fronts(1); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("Fronts(1) = %v, want ErrCountExceedsSize", err)
+	}
+
+	q.Push(1, 2)
+	q.RemoveAll()
+	if !q.Empty() || len(q.ToSlice()) != 0 {
+		t.Fatalf("RemoveAll() left %v", q.ToSlice())
+	}
+}
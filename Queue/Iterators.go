@@ -0,0 +1,68 @@
+package Queue
+
+import "iter"
+
+func (q *queueStruct) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromFront(q.ToSlice())(yield)
+	}
+}
+
+func (q *queueStruct) Drain() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for !q.Empty() {
+			elem, _ := q.FrontAndPop()
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+func (q *genericQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		allFromFront(q.ToSlice())(yield)
+	}
+}
+
+func (q *genericQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !q.Empty() {
+			elem, _ := q.FrontAndPop()
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over a snapshot taken under the read lock, see queueStruct.All
+func (cq *concurrentQueue) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromFront(cq.ToSlice())(yield)
+	}
+}
+
+// Drain pops every element with FrontAndPop, so each element is yielded to only one of
+// the goroutines draining the same queue
+func (cq *concurrentQueue) Drain() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for {
+			elem, err := cq.FrontAndPop()
+			if err != nil || !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// allFromFront returns an iterator over queue (stored front first) from the front to the back
+func allFromFront[T any](queue []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, elem := range queue {
+			if !yield(i+1, elem) {
+				return
+			}
+		}
+	}
+}
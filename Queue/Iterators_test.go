@@ -0,0 +1,77 @@
+package Queue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueueAll(t *testing.T) {
+	q := Queue()
+	_ = q.Push("a", "b", "c")
+
+	var positions []int
+	var elems []interface{}
+	for position, elem := range q.All() {
+		positions = append(positions, position)
+		elems = append(elems, elem)
+		// the loop walks a snapshot
+		_ = q.Pop()
+	}
+	if !reflect.DeepEqual(positions, []int{1, 2, 3}) || !reflect.DeepEqual(elems, []interface{}{"a", "b", "c"}) {
+		t.Fatalf("All() yielded %v %v, want [1 2 3] [a b c]", positions, elems)
+	}
+
+	gq := New[int]()
+	gq.Push(1, 2, 3)
+	count := 0
+	for range gq.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("breaking out of the loop ran %d iterations, want 1", count)
+	}
+}
+
+func TestQueueDrain(t *testing.T) {
+	q := New[int]()
+	for range q.Drain() {
+		t.Fatal("Drain() of an empty queue yielded an element")
+	}
+
+	q.Push(1, 2, 3)
+	var drained []int
+	for elem := range q.Drain() {
+		drained = append(drained, elem)
+		if elem == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(drained, []int{1, 2}) || !reflect.DeepEqual(q.ToSlice(), []int{3}) {
+		t.Fatalf("Drain() with a break yielded %v and left %v, want [1 2] and [3]", drained, q.ToSlice())
+	}
+
+	drained = nil
+	for elem := range q.Drain() {
+		drained = append(drained, elem)
+		if elem == 3 {
+			q.Push(5)
+		}
+	}
+	if !reflect.DeepEqual(drained, []int{3, 5}) || !q.Empty() {
+		t.Fatalf("Drain() yielded %v, want [3 5] including the element pushed in the loop", drained)
+	}
+
+	cq := NewConcurrent()
+	for range cq.Drain() {
+		t.Fatal("concurrent Drain() of an empty queue yielded an element")
+	}
+	_ = cq.Push(1, 2)
+	drained = nil
+	for elem := range cq.Drain() {
+		drained = append(drained, elem.(int))
+	}
+	if !reflect.DeepEqual(drained, []int{1, 2}) || !cq.Empty() {
+		t.Fatalf("concurrent Drain() yielded %v, want [1 2]", drained)
+	}
+}
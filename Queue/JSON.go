@@ -0,0 +1,41 @@
+package Queue
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Codec"
+
+func (q *queueStruct) MarshalJSON() ([]byte, error) {
+	return Codec.MarshalJSON(q.queueDataKind, q.ToSlice())
+}
+
+func (q *queueStruct) UnmarshalJSON(data []byte) error {
+	kind, elems, err := Codec.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := q.checkCapacity(len(elems)); err != nil {
+		return err
+	}
+
+	decoded := Queue()
+	decoded.equal = q.equal
+	decoded.queueDataKind = kind
+	if err := decoded.Push(elems...); err != nil {
+		return err
+	}
+
+	q.queue = decoded.queue
+	q.queueDataKind = decoded.queueDataKind
+	return nil
+}
+
+func (cq *concurrentQueue) MarshalJSON() ([]byte, error) {
+	cq.mu.RLock()
+	defer cq.mu.RUnlock()
+	return cq.q.MarshalJSON()
+}
+
+func (cq *concurrentQueue) UnmarshalJSON(data []byte) error {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	defer cq.room.Broadcast()
+	return cq.q.UnmarshalJSON(data)
+}
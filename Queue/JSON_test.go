@@ -0,0 +1,108 @@
+package Queue
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestQueueJSONRoundTrip(t *testing.T) {
+	q := Queue()
+	_ = q.Push(3, 1, 2)
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"int","elements":[3,1,2]}` {
+		t.Fatalf("Marshal() = %s", data)
+	}
+
+	decoded := Queue()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.ToSlice(); !reflect.DeepEqual(got, []interface{}{3, 1, 2}) || decoded.queueDataKind != reflect.Int {
+		t.Fatalf("decoded %v of kind %v, want [3 1 2] of kind int", got, decoded.queueDataKind)
+	}
+}
+
+func TestQueueJSONEmptyAndCleared(t *testing.T) {
+	q := Queue()
+	_ = q.Push("a")
+	_ = q.Pop()
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"string","elements":[]}` {
+		t.Fatalf("Marshal() of an emptied queue = %s", data)
+	}
+	decoded := Queue()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Size() != 0 || decoded.queueDataKind != reflect.String {
+		t.Fatalf("decoded size %d and kind %v, want an empty string queue", decoded.Size(), decoded.queueDataKind)
+	}
+
+	q.Clear()
+	if data, err = json.Marshal(q); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"","elements":[]}` {
+		t.Fatalf("Marshal() of a cleared queue = %s", data)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.queueDataKind != reflect.Invalid {
+		t.Fatalf("decoded kind %v, want no kind", decoded.queueDataKind)
+	}
+}
+
+func TestQueueJSONNamedType(t *testing.T) {
+	type ID int
+	q := Queue()
+	_ = q.Push(ID(7))
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Queue()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if front, _ := decoded.Front(); front != 7 {
+		t.Fatalf("decoded.Front() = %#v, want int 7", front)
+	}
+}
+
+func TestQueueJSONRejectsStructs(t *testing.T) {
+	type point struct{ X, Y int }
+	q := Queue()
+	_ = q.Push(point{1, 2})
+	if _, err := json.Marshal(q); err == nil {
+		t.Fatal("Marshal() of a struct queue succeeded, the document couldn't be decoded")
+	}
+}
+
+func TestQueueJSONRejectsBadInput(t *testing.T) {
+	inputs := []string{
+		`not json`,
+		`{"kind":"int","elements":["a"]}`,
+		`{"kind":"int","elements":[1.5]}`,
+		`{"kind":"int8","elements":[300]}`,
+		`{"kind":"struct","elements":[]}`,
+		`{"kind":"","elements":[1]}`,
+	}
+	for _, input := range inputs {
+		q := Queue()
+		_ = q.Push(1, 2)
+		if err := json.Unmarshal([]byte(input), q); err == nil {
+			t.Fatalf("Unmarshal(%s) succeeded", input)
+		}
+		if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) || q.queueDataKind != reflect.Int {
+			t.Fatalf("a failed Unmarshal(%s) changed the queue to %v of kind %v", input, got, q.queueDataKind)
+		}
+	}
+}
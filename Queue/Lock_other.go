@@ -0,0 +1,18 @@
+//go:build !unix
+
+package Queue
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// lockDir opens the LOCK file of dir, the file isn't locked as flock is only available on unix
+func lockDir(dir string) (*os.File, error) {
+	return os.OpenFile(filepath.Join(dir, walLockFile), os.O_CREATE|os.O_RDWR, 0o644)
+}
+
+// syncDir does nothing as directories can't be flushed on this platform
+func syncDir(dir string) error {
+	return nil
+}
@@ -0,0 +1,198 @@
+package Queue
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Ring"
+)
+
+// Extreme decides which element a monotonic queue keeps at its Front()
+type Extreme int
+
+const (
+	// Maximum keeps the largest element at the Front()
+	Maximum Extreme = iota
+
+	// Minimum keeps the smallest element at the Front()
+	Minimum
+)
+
+// NewMonotonic a global function which creates, initializes and returns a monotonic queue instance
+// for ordered element types i.e. ints, uints, floats and strings
+func NewMonotonic[T cmp.Ordered](extreme Extreme) *monotonicQueue[T] {
+	return NewMonotonicFunc(cmp.Less[T], extreme)
+}
+
+// NewMonotonicFunc creates, initializes and returns a monotonic queue instance for any element type
+// less reports whether a is ordered before b
+func NewMonotonicFunc[T any](less func(a, b T) bool, extreme Extreme) *monotonicQueue[T] {
+	return &monotonicQueue[T]{
+		queue:   Ring.New[T](),
+		less:    less,
+		extreme: extreme,
+	}
+}
+
+// monotonicQueue a queue whose elements are kept monotonic from the Front() to the back
+// an element which can never become the extreme i.e. an earlier element beaten by a later one
+// is evicted by Push, so the Front() is always the extreme of the elements pushed since the last retired one
+// the data are kept in a circular buffer, so every element is pushed and evicted once and
+// Push is amortized O(1)
+type monotonicQueue[T any] struct {
+	queue   *Ring.Ring[T]
+	less    func(a, b T) bool
+	extreme Extreme
+}
+
+// monotonicQueueMethods stores interface declaration of all monotonicQueue methods
+type monotonicQueueMethods[T any] interface {
+	// Push adds one or more elements to the back of the queue
+	// evicting every earlier element beaten by the pushed one, equal elements are kept
+	Push(elem ...T)
+
+	// Pop removes the Front() element
+	// returns error if the queue is empty
+	Pop() error
+
+	// PopIf removes the Front() elements as long as expired returns true for them
+	// e.g. the elements which left a sliding window, returns the number of removed elements
+	PopIf(expired func(elem T) bool) int
+
+	// Front returns the extreme i.e. the largest (Maximum) or smallest (Minimum) element of the queue
+	// and error (if queue is empty)
+	Front() (T, error)
+
+	// RemoveAll removes all elements from the queue
+	RemoveAll()
+
+	// Size returns the number of elements kept by the queue, evicted elements are not counted
+	Size() int
+
+	// Empty checks whether the queue is empty or not
+	Empty() bool
+
+	// Display prints the kept elements as slice on console screen from the Front() to the back
+	Display()
+
+	// ToSlice returns a copy of the kept elements from the Front() to the back
+	ToSlice() []T
+
+	// private methods (for internal use only)
+
+	// beats reports whether a is strictly more extreme than b
+	beats(a, b T) bool
+}
+
+func (mq *monotonicQueue[T]) Push(elem ...T) {
+	for _, e := range elem {
+		for mq.queue.Len() > 0 && mq.beats(e, mq.queue.Back()) {
+			mq.queue.PopBack()
+		}
+		mq.queue.PushBack(e)
+	}
+}
+
+func (mq *monotonicQueue[T]) Pop() error {
+	if mq.Empty() {
+		return ErrEmpty
+	}
+
+	mq.queue.PopFront()
+	return nil
+}
+
+func (mq *monotonicQueue[T]) PopIf(expired func(elem T) bool) int {
+	popCount := 0
+	for mq.queue.Len() > 0 && expired(mq.queue.At(0)) {
+		mq.queue.PopFront()
+		popCount++
+	}
+	return popCount
+}
+
+func (mq *monotonicQueue[T]) Front() (T, error) {
+	if mq.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return mq.queue.At(0), nil
+}
+
+func (mq *monotonicQueue[T]) RemoveAll() {
+	mq.queue = Ring.New[T]()
+}
+
+func (mq *monotonicQueue[T]) Size() int {
+	return mq.queue.Len()
+}
+
+func (mq *monotonicQueue[T]) Empty() bool {
+	return mq.Size() == 0
+}
+
+func (mq *monotonicQueue[T]) Display() {
+	fmt.Println(mq.ToSlice())
+}
+
+func (mq *monotonicQueue[T]) ToSlice() []T {
+	return mq.queue.Slice(0, mq.Size())
+}
+
+func (mq *monotonicQueue[T]) beats(a, b T) bool {
+	if mq.extreme == Minimum {
+		return mq.less(a, b)
+	}
+	return mq.less(b, a)
+}
+
+// SlidingWindow returns an iterator over the extremes of every window of size consecutive values
+// it yields the index of the first value of the window and the extreme of the window,
+// the first window is yielded once size values are read, no window is yielded if size <= 0
+func SlidingWindow[T cmp.Ordered](values iter.Seq[T], size int, extreme Extreme) iter.Seq2[int, T] {
+	return SlidingWindowFunc(values, size, cmp.Less[T], extreme)
+}
+
+// SlidingWindowFunc is SlidingWindow for any value type, less reports whether a is ordered before b
+func SlidingWindowFunc[T any](values iter.Seq[T], size int, less func(a, b T) bool, extreme Extreme) iter.Seq2[int, T] {
+	type indexed struct {
+		index int
+		value T
+	}
+
+	return func(yield func(int, T) bool) {
+		if size <= 0 {
+			return
+		}
+
+		mq := NewMonotonicFunc(func(a, b indexed) bool { return less(a.value, b.value) }, extreme)
+		index := 0
+		for value := range values {
+			mq.Push(indexed{index: index, value: value})
+			start := index - size + 1
+			mq.PopIf(func(elem indexed) bool { return elem.index < start })
+			index++
+
+			if start < 0 {
+				continue
+			}
+			front, _ := mq.Front()
+			if !yield(start, front.value) {
+				return
+			}
+		}
+	}
+}
+
+// SlidingWindowSlice returns the extremes of every window of size consecutive values of the slice
+// the i-th extreme belongs to the window starting at values[i], it is empty if size <= 0 or size > len(values)
+func SlidingWindowSlice[T cmp.Ordered](values []T, size int, extreme Extreme) []T {
+	extremes := make([]T, 0)
+	for _, e := range SlidingWindow(slices.Values(values), size, extreme) {
+		extremes = append(extremes, e)
+	}
+	return extremes
+}
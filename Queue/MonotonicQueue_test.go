@@ -0,0 +1,101 @@
+package Queue
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// bruteWindows returns the extremes of every window of size consecutive values by scanning every window
+func bruteWindows(values []int, size int, extreme Extreme) []int {
+	extremes := make([]int, 0)
+	for start := 0; size > 0 && start+size <= len(values); start++ {
+		window := values[start : start+size]
+		if extreme == Maximum {
+			extremes = append(extremes, slices.Max(window))
+		} else {
+			extremes = append(extremes, slices.Min(window))
+		}
+	}
+	return extremes
+}
+
+func TestSlidingWindowMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		values := make([]int, rng.Intn(30))
+		for i := range values {
+			// few distinct values so equal elements are common
+			values[i] = rng.Intn(5)
+		}
+		for size := -1; size <= len(values)+1; size++ {
+			for _, extreme := range []Extreme{Maximum, Minimum} {
+				got := SlidingWindowSlice(values, size, extreme)
+				if want := bruteWindows(values, size, extreme); !slices.Equal(got, want) {
+					t.Fatalf("SlidingWindowSlice(%v, %d, %v) = %v, want %v", values, size, extreme, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSlidingWindowIndexesAndBreak(t *testing.T) {
+	values := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	starts := make([]int, 0)
+	for start, extreme := range SlidingWindow(slices.Values(values), 3, Maximum) {
+		starts = append(starts, start)
+		if extreme != slices.Max(values[start:start+3]) {
+			t.Fatalf("window %d: extreme %d, want %d", start, extreme, slices.Max(values[start:start+3]))
+		}
+		if start == 2 {
+			break
+		}
+	}
+	if !slices.Equal(starts, []int{0, 1, 2}) {
+		t.Fatalf("window starts = %v, want [0 1 2]", starts)
+	}
+}
+
+func TestSlidingWindowFunc(t *testing.T) {
+	words := []string{"go", "stack", "a", "queue", "set", "b"}
+	longer := func(a, b string) bool { return len(a) < len(b) }
+	got := make([]string, 0)
+	for _, word := range SlidingWindowFunc(slices.Values(words), 2, longer, Maximum) {
+		got = append(got, word)
+	}
+	// the earlier of two equally long words is the extreme
+	if want := []string{"stack", "stack", "queue", "queue", "set"}; !slices.Equal(got, want) {
+		t.Fatalf("longest words = %v, want %v", got, want)
+	}
+	if got := strings.Join(SlidingWindowSlice(words, 7, Minimum), ","); got != "" {
+		t.Fatalf("windows larger than the values = %q, want none", got)
+	}
+}
+
+func TestMonotonicQueue(t *testing.T) {
+	mq := NewMonotonic[int](Minimum)
+	if _, err := mq.Front(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Front() = %v, want ErrEmpty", err)
+	}
+	if err := mq.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Pop() = %v, want ErrEmpty", err)
+	}
+
+	mq.Push(5, 3, 4, 3, 6)
+	// 5 and 4 are beaten by the later 3s, the equal 3s are both kept
+	if got := mq.ToSlice(); !slices.Equal(got, []int{3, 3, 6}) {
+		t.Fatalf("ToSlice() = %v, want [3 3 6]", got)
+	}
+	if popped := mq.PopIf(func(elem int) bool { return elem == 3 }); popped != 2 {
+		t.Fatalf("PopIf() = %d, want 2", popped)
+	}
+	if front, _ := mq.Front(); front != 6 || mq.Size() != 1 {
+		t.Fatalf("Front() = %d with Size() %d, want 6 with 1", front, mq.Size())
+	}
+	mq.RemoveAll()
+	if !mq.Empty() {
+		t.Fatal("RemoveAll() left elements")
+	}
+}
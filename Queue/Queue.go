@@ -1,37 +1,46 @@
 package Queue
 
 import (
-	"errors"
 	"fmt"
+	"iter"
 	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Bounded"
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+	"github.com/FahimSifnatul/goDataStructures/internal/Ring"
 )
 
 // Queue a global function which creates, initializes and returns a queue instance
-func Queue() *queueStruct {
-	return &queueStruct{
-		queue: make([]interface{}, 0),
+// optional Option values (e.g. WithEqual) can be passed to configure the queue
+func Queue(opts ...Option) *queueStruct {
+	q := &queueStruct{
+		queue: Ring.New[interface{}](),
+	}
+	for _, opt := range opts {
+		opt(q)
 	}
+	return q
 }
 
-// not supported data kinds are stored here
-var (
-	invalidKind = []reflect.Kind{
-		reflect.Array,
-		reflect.Chan,
-		reflect.Func,
-		reflect.Interface,
-		reflect.Map,
-		reflect.Ptr,
-		reflect.Slice,
-		reflect.Struct,
-		reflect.UnsafePointer,
+// Option configures a queue created by Queue()
+type Option func(*queueStruct)
+
+// WithEqual lets the queue accept elements of any data kind, including the unsupported ones
+// e.g. structs having slice fields, pointers or maps
+// equal is used by Search to compare two elements instead of ==
+func WithEqual(equal func(a, b interface{}) bool) Option {
+	return func(q *queueStruct) {
+		q.equal = equal
 	}
-)
+}
 
 // queueStruct where queue data are stored
+// the data are kept in a circular buffer, so Push, Pop and Front are amortized O(1)
 type queueStruct struct {
-	queue         []interface{}
+	queue         *Ring.Ring[interface{}]
 	queueDataKind reflect.Kind
+	equal         func(a, b interface{}) bool
+	limit         Bounded.Limit
 }
 
 type queueMethods interface {
@@ -39,6 +48,7 @@ type queueMethods interface {
 
 	// Push adds one or more elements to an existing queue.
 	// returns error if data types mismatched and also doesn't push any value to the queue
+	// a queue created WithCapacity applies its OverflowPolicy when the elements don't fit
 	Push(elem ...interface{}) error
 
 	// Pop removes the earliest inserted element from the caller queue
@@ -46,7 +56,7 @@ type queueMethods interface {
 
 	// Pops can pop multiple elements from the top of the queue
 	// all you have to do is to provide an int type number i.e. how many element to pop
-	// returns error if the popCount number is bigger than the size of the queue
+	// returns error if the popCount number is negative or bigger than the size of the queue
 	Pops(popCount int) error
 
 	// RemoveAll it removes all elements from the caller queue
@@ -63,14 +73,14 @@ type queueMethods interface {
 	// suppose, data type of the caller queue is int
 	// now caller queue calls this function then
 	// it will remove all elements from the queue and
-	// any data except invalidKind types can be inserted for this queue
+	// any data except Codec.UnsupportedKinds types can be inserted for this queue
 	Clear()
 
 	// Front returns the front element i.e. first inserted element from the queue
 	// and error (if queue is empty)
 	Front() (interface{}, error)
 
-	// Fronts returns the earliest inserted elements equal to frontCount (stored in a slice)
+	// Fronts returns the earliest inserted elements equal to frontCount (stored in a new slice)
 	// and error (if any)
 	Fronts(frontCount int) ([]interface{}, error)
 
@@ -102,16 +112,74 @@ type queueMethods interface {
 	// and the right most data is the last inserted value
 	Display()
 
-	// ToSlice returns the queue as slice
+	// ToSlice returns a copy of the queue as slice
+	// changing the returned slice doesn't change the queue, use View to read the queue without copying
 	ToSlice() []interface{}
 
+	// View returns a read-only view of the queue which doesn't copy the elements
+	View() View[interface{}]
+
+	// MarshalJSON encodes the queue as a JSON object having the data kind and the elements
+	// e.g. {"kind":"int","elements":[1,2,3]}, the elements are ordered from the Front() to the back
+	// returns error if the data kind isn't supported by internal/Codec e.g. a struct
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON replaces the elements and the data kind of the queue with the decoded ones
+	// every element is validated like Push, so a malformed document is rejected and the queue is not changed
+	// an element is decoded as the builtin type of the data kind, so elements of a named type e.g. type ID int come back as int
+	UnmarshalJSON(data []byte) error
+
+	// MarshalBinary encodes the queue in the compact binary format of the package internal/Codec
+	// the encoded data has a versioned header with the data kind followed by the elements from the Front() to the back
+	MarshalBinary() ([]byte, error)
+
+	// UnmarshalBinary replaces the elements and the data kind of the queue with the decoded ones
+	// every element is validated like Push, so malformed data is rejected and the queue is not changed
+	UnmarshalBinary(data []byte) error
+
+	// GobEncode and GobDecode let encoding/gob use the binary format
+	GobEncode() ([]byte, error)
+	GobDecode(data []byte) error
+
+	// All returns an iterator over the queue from the Front() to the back
+	// it yields the position of every element (Front() is taken as position 1, like Search) and the element
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so pushing to or popping from the queue inside the loop doesn't change what is yielded
+	All() iter.Seq2[int, interface{}]
+
+	// Drain returns an iterator which pops and yields the Front() element until the queue is empty
+	// or the loop stops, elements pushed inside the loop are drained too
+	Drain() iter.Seq[interface{}]
+
+	// Capacity returns the capacity set by WithCapacity, 0 or less means unbounded
+	Capacity() int
+
+	// Overflows returns how many elements the overflow policy rejected or dropped
+	Overflows() OverflowStats
+
 	// private methods (for internal use only)
 
+	// makeRoom applies the overflow policy before pushing count elements
+	// returns whether the elements are to be pushed and the error of a rejected push
+	makeRoom(count int) (bool, error)
+
+	// checkCapacity checks whether count decoded elements fit in the queue
+	checkCapacity(count int) error
+
+	// mustWait checks whether a Push of the elements has to wait for room under the Block policy
+	mustWait(elem []interface{}) bool
+
+	// unbounded removes the capacity set by WithCapacity and returns the queue
+	unbounded() *queueStruct
+
 	// checkDataKind checks the data kind of the elements of a queue
 	// when adding an element to a queue, at first the data kind is checked by this function
 	// the queue data kind is of type builtin reflect.Kind
 	// a queue must contain elements having same data kind
 	checkDataKind(value interface{}) error
+
+	// isEqual compares two elements using the equal function (if provided) else ==
+	isEqual(a, b interface{}) bool
 }
 
 func (q *queueStruct) Push(elem ...interface{}) error {
@@ -121,29 +189,30 @@ func (q *queueStruct) Push(elem ...interface{}) error {
 		}
 	}
 
+	if push, err := q.makeRoom(len(elem)); !push {
+		return err
+	}
 	for _, e := range elem {
-		q.queue = append(q.queue, e)
+		q.queue.PushBack(e)
 	}
 	return nil
 }
 
 func (q *queueStruct) Pop() error {
 	if q.Empty() {
-		return errors.New("invalid operation as queue is empty")
+		return ErrEmpty
 	}
 
-	q.queue = q.queue[1:]
+	q.queue.PopFront()
 	return nil
 }
 
 func (q *queueStruct) Pops(popCount int) error {
-	queueSize := q.Size()
-	if popCount > queueSize {
-		errMsg := "invalid operation as pop count (%d) is greater than queue size(%d)"
-		return fmt.Errorf(errMsg, popCount, queueSize)
+	if err := checkCount("pop", popCount, q.Size()); err != nil {
+		return err
 	}
 
-	q.queue = q.queue[popCount:]
+	q.queue.PopFronts(popCount)
 	return nil
 }
 
@@ -161,20 +230,18 @@ func (q *queueStruct) Clear() {
 func (q *queueStruct) Front() (interface{}, error) {
 	queueSize := q.Size()
 	if queueSize == 0 {
-		return nil, errors.New("invalid operation as queue is empty")
+		return nil, ErrEmpty
 	}
 
-	return q.queue[0], nil
+	return q.queue.At(0), nil
 }
 
 func (q *queueStruct) Fronts(frontCount int) ([]interface{}, error) {
-	queueSize := q.Size()
-	if frontCount > queueSize {
-		errMsg := "invalid operation as front count (%d) is greater than the queue size(%d)"
-		return nil, fmt.Errorf(errMsg, frontCount, queueSize)
+	if err := checkCount("front", frontCount, q.Size()); err != nil {
+		return nil, err
 	}
 
-	return q.queue[:frontCount], nil
+	return q.queue.Slice(0, frontCount), nil
 }
 
 func (q *queueStruct) FrontAndPop() (interface{}, error) {
@@ -200,7 +267,7 @@ func (q *queueStruct) FrontsAndPops(count int) ([]interface{}, error) {
 }
 
 func (q *queueStruct) Size() int {
-	return len(q.queue)
+	return q.queue.Len()
 }
 
 func (q *queueStruct) Empty() bool {
@@ -213,7 +280,7 @@ func (q *queueStruct) Empty() bool {
 func (q *queueStruct) Search(elem interface{}) int {
 	queueSize := q.Size()
 	for i := 0; i < queueSize; i++ {
-		if q.queue[i] == elem {
+		if q.isEqual(q.queue.At(i), elem) {
 			return i + 1
 		}
 	}
@@ -221,29 +288,31 @@ func (q *queueStruct) Search(elem interface{}) int {
 }
 
 func (q *queueStruct) Display() {
-	fmt.Println(q.queue)
+	fmt.Println(q.ToSlice())
 }
 
 func (q *queueStruct) ToSlice() []interface{} {
-	return q.queue
+	return q.queue.Slice(0, q.Size())
 }
 
 func (q *queueStruct) checkDataKind(val interface{}) error {
 	valKind := reflect.TypeOf(val).Kind()
 
-	if q.queueDataKind != reflect.Invalid {
-		if q.queueDataKind != valKind {
-			return errors.New("invalid value type")
-		}
-		return nil
+	if q.queueDataKind != reflect.Invalid && q.queueDataKind != valKind {
+		return &KindMismatchError{Expected: q.queueDataKind, Got: valKind}
 	}
 
-	for _, kind := range invalidKind {
-		if valKind == kind {
-			return fmt.Errorf("%v is not supported type for queue", valKind)
-		}
+	if q.equal == nil && !Codec.IsSupportedType(reflect.TypeOf(val)) {
+		return &UnsupportedKindError{Kind: valKind}
 	}
 
 	q.queueDataKind = valKind
 	return nil
 }
+
+func (q *queueStruct) isEqual(a, b interface{}) bool {
+	if q.equal != nil {
+		return q.equal(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,118 @@
+package Queue
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestQueueRejectsNegativeCount(t *testing.T) {
+	q := Queue()
+	_ = q.Push(1, 2, 3)
+	if err := q.Pops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Pops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := q.Fronts(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Fronts(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := q.FrontsAndPops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("FrontsAndPops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if err := q.Pops(4); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("Pops(4) = %v, want ErrCountExceedsSize", err)
+	}
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("rejected counts changed the queue to %v", got)
+	}
+}
+
+func TestGenericQueueRejectsNegativeCount(t *testing.T) {
+	q := New[int]()
+	q.Push(1, 2, 3)
+	if err := q.Pops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Pops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := q.Fronts(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Fronts(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := q.FrontsAndPops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("FrontsAndPops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("rejected counts changed the queue to %v", got)
+	}
+}
+
+func TestQueueRingWrapAround(t *testing.T) {
+	q := New[int]()
+	want := make([]int, 0)
+	next := 0
+	for round := 0; round < 50; round++ {
+		for i := 0; i < round%7+1; i++ {
+			q.Push(next)
+			want = append(want, next)
+			next++
+		}
+		popCount := round % 5
+		if popCount > len(want) {
+			popCount = len(want)
+		}
+		popped, err := q.FrontsAndPops(popCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(popped, want[:popCount]) {
+			t.Fatalf("round %d: popped %v, want %v", round, popped, want[:popCount])
+		}
+		want = want[popCount:]
+		if got := q.ToSlice(); !reflect.DeepEqual(got, want) && !(len(got) == 0 && len(want) == 0) {
+			t.Fatalf("round %d: queue is %v, want %v", round, got, want)
+		}
+	}
+}
+
+func TestQueueElementsComparedByEqual(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	var unsupported *UnsupportedKindError
+	if err := Queue().Push(record{ID: 1}); !errors.As(err, &unsupported) {
+		t.Fatalf("Push() of a struct having a slice field without WithEqual = %v, want *UnsupportedKindError", err)
+	}
+
+	q := Queue(WithEqual(func(a, b interface{}) bool {
+		return a.(record).ID == b.(record).ID
+	}))
+	if err := q.Push(record{1, []string{"a"}}, record{2, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Search(record{ID: 2}); got != 2 {
+		t.Fatalf("Search() = %d, want 2", got)
+	}
+}
+
+func TestQueuePointerAndArrayElements(t *testing.T) {
+	x, y := 1, 1
+	var unsupported *UnsupportedKindError
+	if err := Queue().Push(&x); !errors.As(err, &unsupported) {
+		t.Fatalf("Push() of a pointer without WithEqual = %v, want *UnsupportedKindError", err)
+	}
+	q := Queue(WithEqual(func(a, b interface{}) bool { return *a.(*int) == *b.(*int) }))
+	_ = q.Push(&x)
+	if got := q.Search(&y); got != 1 {
+		t.Fatalf("Search() of an equal pointee = %d, want 1", got)
+	}
+
+	arrays := Queue()
+	if err := arrays.Push([2]int{1, 2}, [2]int{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if got := arrays.Search([2]int{3, 4}); got != 2 {
+		t.Fatalf("Search() of an array = %d, want 2", got)
+	}
+	type boxed struct{ V interface{} }
+	if err := Queue().Push(boxed{[]int{1}}); !errors.As(err, &unsupported) {
+		t.Fatalf("Push() of a struct having an interface field = %v, want *UnsupportedKindError", err)
+	}
+}
@@ -0,0 +1,244 @@
+package Queue
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Ring"
+)
+
+// NewReliable a global function which creates, initializes and returns a reliable queue instance
+// a reserved element stays hidden for visibilityTimeout, if it isn't acknowledged in time it becomes visible again
+// an element which is reserved maxDeliveries times without an Ack is moved to the dead-letter queue,
+// maxDeliveries <= 0 means no limit
+// clock is the source of time of the visibility timeouts, nil means the real time
+// it accepts the same Option values as Queue(), the dead-letter queue is created with them too
+func NewReliable(visibilityTimeout time.Duration, maxDeliveries int, clock Clock, opts ...Option) *reliableQueue {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	deadLetters := NewConcurrent(opts...)
+	deadLetters.q.unbounded()
+	return &reliableQueue{
+		q:                 Queue(opts...).unbounded(),
+		clock:             clock,
+		deliveries:        Ring.New[int](),
+		inFlight:          make(map[uint64]*reservation),
+		reservations:      Ring.New[*reservation](),
+		deadLetters:       deadLetters,
+		visibilityTimeout: visibilityTimeout,
+		maxDeliveries:     maxDeliveries,
+	}
+}
+
+// Receipt identifies one delivery of a reserved element, it is passed to Ack or Nack
+// a receipt is valid until it is acknowledged, negatively acknowledged or its visibility timeout passes
+type Receipt struct {
+	id         uint64
+	deliveries int
+}
+
+// DeliveryCount returns how many times the element has been reserved, including this delivery
+func (r Receipt) DeliveryCount() int {
+	return r.deliveries
+}
+
+// reservation an element which is reserved and hidden until its deadline
+type reservation struct {
+	id         uint64
+	elem       interface{}
+	deliveries int
+	deadline   time.Time
+}
+
+// reliableQueue a queueStruct whose consumers reserve elements instead of popping them,
+// so an element isn't lost when its consumer crashes before finishing it
+// the visible elements are kept in q with their delivery counts in the parallel ring deliveries
+// the reserved ones are kept by receipt id in inFlight and in reservation order in reservations,
+// every reservation has the same visibility timeout so the earliest deadline is always at the front
+// expired reservations are returned to the back of the queue by the next method call,
+// the error of returning them (if any) is kept in releaseErr until Err returns it
+// it is safe to use from multiple goroutines
+type reliableQueue struct {
+	mu                sync.Mutex
+	q                 *queueStruct
+	clock             Clock
+	deliveries        *Ring.Ring[int]
+	inFlight          map[uint64]*reservation
+	reservations      *Ring.Ring[*reservation]
+	deadLetters       *concurrentQueue
+	visibilityTimeout time.Duration
+	maxDeliveries     int
+	nextID            uint64
+	releaseErr        error
+}
+
+type reliableQueueMethods interface {
+	// Push adds one or more elements to the back of the queue with a delivery count of 0
+	// returns error if data types mismatched and also doesn't push any value to the queue
+	Push(elem ...interface{}) error
+
+	// Reserve hides the front element for the visibility timeout and returns it with its receipt
+	// the delivery count of the element is incremented, returns ErrEmpty if no element is visible
+	Reserve() (interface{}, Receipt, error)
+
+	// Ack removes the element of the receipt for good
+	// returns ErrInvalidReceipt if the receipt is already used or its visibility timeout has passed
+	Ack(receipt Receipt) error
+
+	// Nack makes the element of the receipt visible again at the back of the queue right away
+	// or moves it to the dead-letter queue if it has been delivered maxDeliveries times
+	// returns ErrInvalidReceipt if the receipt is already used or its visibility timeout has passed
+	// and the error of the dead-letter queue, the element is then made visible again instead of being lost
+	Nack(receipt Receipt) error
+
+	// DeadLetters returns the goroutine safe queue holding the elements which failed maxDeliveries times
+	DeadLetters() *concurrentQueue
+
+	// Err returns the errors of returning expired reservations since the previous Err (nil if none) and forgets them
+	// an expired element rejected by the dead-letter queue is made visible again, so it isn't lost
+	Err() error
+
+	// Size returns the number of visible elements i.e. the elements which can be reserved
+	Size() int
+
+	// InFlight returns the number of reserved elements which are neither acknowledged nor expired
+	InFlight() int
+
+	// Empty checks whether there is no visible and no reserved element
+	Empty() bool
+
+	// private methods (for internal use only)
+
+	// expire returns the reservations whose deadline has passed, the lock must be held
+	// the errors of release are joined to releaseErr
+	expire()
+
+	// release makes a reserved element visible again or moves it to the dead-letter queue, the lock must be held
+	// if the dead-letter queue rejects the element it is made visible again and the error is returned
+	release(r *reservation) error
+}
+
+func (rq *reliableQueue) Push(elem ...interface{}) error {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	if err := rq.q.Push(elem...); err != nil {
+		return err
+	}
+	for range elem {
+		rq.deliveries.PushBack(0)
+	}
+	return nil
+}
+
+func (rq *reliableQueue) Reserve() (interface{}, Receipt, error) {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+
+	elem, err := rq.q.FrontAndPop()
+	if err != nil {
+		return nil, Receipt{}, err
+	}
+	rq.nextID++
+	r := &reservation{
+		id:         rq.nextID,
+		elem:       elem,
+		deliveries: rq.deliveries.PopFront() + 1,
+		deadline:   rq.clock.Now().Add(rq.visibilityTimeout),
+	}
+	rq.inFlight[r.id] = r
+	rq.reservations.PushBack(r)
+	return elem, Receipt{id: r.id, deliveries: r.deliveries}, nil
+}
+
+func (rq *reliableQueue) Ack(receipt Receipt) error {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+
+	if _, ok := rq.inFlight[receipt.id]; !ok {
+		return ErrInvalidReceipt
+	}
+	delete(rq.inFlight, receipt.id)
+	return nil
+}
+
+func (rq *reliableQueue) Nack(receipt Receipt) error {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+
+	r, ok := rq.inFlight[receipt.id]
+	if !ok {
+		return ErrInvalidReceipt
+	}
+	delete(rq.inFlight, receipt.id)
+	return rq.release(r)
+}
+
+func (rq *reliableQueue) DeadLetters() *concurrentQueue {
+	return rq.deadLetters
+}
+
+func (rq *reliableQueue) Err() error {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+	err := rq.releaseErr
+	rq.releaseErr = nil
+	return err
+}
+
+func (rq *reliableQueue) Size() int {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+	return rq.q.Size()
+}
+
+func (rq *reliableQueue) InFlight() int {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+	return len(rq.inFlight)
+}
+
+func (rq *reliableQueue) Empty() bool {
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	rq.expire()
+	return rq.q.Empty() && len(rq.inFlight) == 0
+}
+
+func (rq *reliableQueue) expire() {
+	now := rq.clock.Now()
+	for rq.reservations.Len() > 0 {
+		r := rq.reservations.At(0)
+		_, ok := rq.inFlight[r.id]
+		if ok && r.deadline.After(now) {
+			return
+		}
+		// acknowledged and negatively acknowledged reservations are just dropped
+		rq.reservations.PopFront()
+		if ok {
+			delete(rq.inFlight, r.id)
+			rq.releaseErr = errors.Join(rq.releaseErr, rq.release(r))
+		}
+	}
+}
+
+func (rq *reliableQueue) release(r *reservation) error {
+	var deadLetterErr error
+	if rq.maxDeliveries > 0 && r.deliveries >= rq.maxDeliveries {
+		if deadLetterErr = rq.deadLetters.Push(r.elem); deadLetterErr == nil {
+			return nil
+		}
+	}
+	if err := rq.q.Push(r.elem); err != nil {
+		return errors.Join(deadLetterErr, err)
+	}
+	rq.deliveries.PushBack(r.deliveries)
+	return deadLetterErr
+}
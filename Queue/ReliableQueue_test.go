@@ -0,0 +1,98 @@
+package Queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReliableQueueVisibilityTimeout(t *testing.T) {
+	clock := newFakeClock()
+	rq := NewReliable(time.Minute, 0, clock)
+	_ = rq.Push(1, 2)
+
+	elem, receipt, err := rq.Reserve()
+	if err != nil || elem != 1 || receipt.DeliveryCount() != 1 {
+		t.Fatalf("Reserve() = %v, %d, %v, want 1, 1, nil", elem, receipt.DeliveryCount(), err)
+	}
+	clock.Advance(time.Minute - time.Nanosecond)
+	if rq.Size() != 1 || rq.InFlight() != 1 {
+		t.Fatalf("before the timeout Size() = %d and InFlight() = %d, want 1 and 1", rq.Size(), rq.InFlight())
+	}
+
+	clock.Advance(time.Nanosecond)
+	if rq.Size() != 2 || rq.InFlight() != 0 {
+		t.Fatalf("after the timeout Size() = %d and InFlight() = %d, want 2 and 0", rq.Size(), rq.InFlight())
+	}
+	if err := rq.Ack(receipt); !errors.Is(err, ErrInvalidReceipt) {
+		t.Fatalf("Ack of an expired receipt = %v, want ErrInvalidReceipt", err)
+	}
+
+	// the expired element went to the back with its delivery count
+	_, receipt, _ = rq.Reserve()
+	if err := rq.Ack(receipt); err != nil {
+		t.Fatal(err)
+	}
+	elem, receipt, _ = rq.Reserve()
+	if elem != 1 || receipt.DeliveryCount() != 2 {
+		t.Fatalf("Reserve() = %v with %d deliveries, want 1 with 2", elem, receipt.DeliveryCount())
+	}
+	if err := rq.Ack(receipt); err != nil || !rq.Empty() {
+		t.Fatalf("Ack() = %v with Empty() %t, want nil with true", err, rq.Empty())
+	}
+}
+
+func TestReliableQueueDeadLetters(t *testing.T) {
+	clock := newFakeClock()
+	rq := NewReliable(time.Minute, 2, clock)
+	_ = rq.Push("a")
+
+	_, receipt, _ := rq.Reserve()
+	if err := rq.Nack(receipt); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = rq.Reserve()
+	clock.Advance(time.Minute)
+	if !rq.Empty() || rq.DeadLetters().Size() != 1 {
+		t.Fatalf("Empty() = %t with %d dead letters, want true with 1", rq.Empty(), rq.DeadLetters().Size())
+	}
+	if elem, _ := rq.DeadLetters().Front(); elem != "a" {
+		t.Fatalf("dead letter = %v, want a", elem)
+	}
+}
+
+func TestReliableQueueDeadLetterErrors(t *testing.T) {
+	clock := newFakeClock()
+	rq := NewReliable(time.Minute, 1, clock)
+	_ = rq.DeadLetters().Push("not an int")
+	_ = rq.Push(1, 2)
+
+	// Nack returns the error of the dead-letter queue and keeps the element visible
+	_, receipt, _ := rq.Reserve()
+	var mismatch *KindMismatchError
+	if err := rq.Nack(receipt); !errors.As(err, &mismatch) {
+		t.Fatalf("Nack() = %v, want a *KindMismatchError", err)
+	}
+	if rq.Size() != 2 || rq.DeadLetters().Size() != 1 {
+		t.Fatalf("Size() = %d with %d dead letters, want 2 with 1", rq.Size(), rq.DeadLetters().Size())
+	}
+
+	// an expired reservation's error is returned by Err, never by an unrelated Reserve, Ack or Nack
+	_, receipt, _ = rq.Reserve()
+	clock.Advance(time.Minute)
+	if rq.Size() != 2 {
+		t.Fatalf("Size() = %d after the timeout, want 2", rq.Size())
+	}
+	if err := rq.Ack(receipt); !errors.Is(err, ErrInvalidReceipt) {
+		t.Fatalf("Ack() = %v, want ErrInvalidReceipt", err)
+	}
+	if elem, _, err := rq.Reserve(); err != nil || elem != 1 {
+		t.Fatalf("Reserve() = %v, %v, want 1, nil", elem, err)
+	}
+	if err := rq.Err(); !errors.As(err, &mismatch) {
+		t.Fatalf("Err() = %v, want the *KindMismatchError of the expired reservation", err)
+	}
+	if err := rq.Err(); err != nil {
+		t.Fatalf("second Err() = %v, want nil as the error was already returned", err)
+	}
+}
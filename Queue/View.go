@@ -0,0 +1,41 @@
+package Queue
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Ring"
+
+// View a read-only view of a queue which doesn't copy the elements
+// index 0 is the Front() i.e. first inserted element and index Len()-1 is the last inserted one, like ToSlice
+// the view reads the queue directly, so it always shows the current elements of the queue
+type View[T any] struct {
+	queue **Ring.Ring[T]
+}
+
+func (q *queueStruct) View() View[interface{}] {
+	return View[interface{}]{queue: &q.queue}
+}
+
+func (q *genericQueue[T]) View() View[T] {
+	return View[T]{queue: &q.queue}
+}
+
+// Len returns the number of elements in the queue
+func (v View[T]) Len() int {
+	return (*v.queue).Len()
+}
+
+// At returns the element at index i, it panics with a *ViewIndexError if i is out of range
+func (v View[T]) At(i int) T {
+	if i < 0 || i >= v.Len() {
+		panic(&ViewIndexError{Index: i, Len: v.Len()})
+	}
+	return (*v.queue).At(i)
+}
+
+// Range calls f for every element from the Front() to the back until f returns false
+// the queue must not be changed by f
+func (v View[T]) Range(f func(i int, elem T) bool) {
+	for i := 0; i < v.Len(); i++ {
+		if !f(i, (*v.queue).At(i)) {
+			return
+		}
+	}
+}
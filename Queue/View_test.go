@@ -0,0 +1,66 @@
+package Queue
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// atPanic returns the value At(i) panics with, nil if it doesn't panic
+func atPanic[T any](v View[T], i int) (recovered interface{}) {
+	defer func() { recovered = recover() }()
+	v.At(i)
+	return nil
+}
+
+func TestViewAtOutOfRange(t *testing.T) {
+	q := New[int]()
+	q.Push(1, 2, 3)
+	v := q.View()
+	if v.At(0) != 1 || v.At(2) != 3 {
+		t.Fatalf("At(0), At(2) = %d, %d, want 1, 3", v.At(0), v.At(2))
+	}
+	for _, i := range []int{-1, 3} {
+		var indexErr *ViewIndexError
+		err, _ := atPanic(v, i).(error)
+		if !errors.As(err, &indexErr) || indexErr.Index != i || indexErr.Len != 3 {
+			t.Fatalf("At(%d) panicked with %v, want a *ViewIndexError", i, err)
+		}
+	}
+	if got, want := atPanic(v, 3).(error).Error(), "Queue: view index 3 out of range [0, 3)"; got != want {
+		t.Fatalf("At(3) panicked with %q, want %q", got, want)
+	}
+}
+
+func TestQueueSlicesAreCopies(t *testing.T) {
+	q := Queue()
+	_ = q.Push(0, 1, 2, 3)
+	_ = q.Pop()
+	slice := q.ToSlice()
+	slice[0] = 9
+	_ = append(slice[:1], 8)
+	fronts, _ := q.Fronts(2)
+	fronts[0], fronts[1] = 7, 7
+	_ = append(fronts[:1], 6)
+	if got := q.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("changing the returned slices changed the queue to %v", got)
+	}
+
+	// a wrapped around ring is returned in order and still copied
+	gq := New[int]()
+	for i := 0; i < 8; i++ {
+		gq.Push(i)
+	}
+	_ = gq.Pops(6)
+	gq.Push(8, 9, 10)
+	gslice := gq.ToSlice()
+	if !reflect.DeepEqual(gslice, []int{6, 7, 8, 9, 10}) {
+		t.Fatalf("ToSlice() = %v, want [6 7 8 9 10]", gslice)
+	}
+	gslice[0] = 0
+	gfronts, _ := gq.Fronts(3)
+	gfronts[2] = 0
+	if got := gq.ToSlice(); !reflect.DeepEqual(got, []int{6, 7, 8, 9, 10}) {
+		t.Fatalf("changing the returned slices changed the generic queue to %v", got)
+	}
+}
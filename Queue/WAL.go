@@ -0,0 +1,566 @@
+package Queue
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+)
+
+// SyncPolicy decides when the write-ahead log of a durable queue is flushed to stable storage
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes the log after every operation, nothing acknowledged is ever lost
+	SyncAlways SyncPolicy = iota
+
+	// SyncInterval flushes the log in the background once per sync interval (if anything was written),
+	// operations of the last interval can be lost if the machine crashes
+	SyncInterval
+
+	// SyncNever leaves flushing to the operating system
+	SyncNever
+)
+
+const (
+	// walSuffix is the file name suffix of the log segments
+	walSuffix = ".wal"
+
+	// walLockFile is the name of the file locked by the queue having the log open
+	walLockFile = "LOCK"
+
+	// walHeaderSize is the size of the record header: payload length and payload crc32 (4 bytes each)
+	walHeaderSize = 8
+
+	// walMaxRecordSize bounds the payload length read from a record header,
+	// anything bigger can only be a torn or corrupted header
+	walMaxRecordSize = 1 << 30
+
+	// defaultSegmentSize is the size after which a new log segment is started
+	defaultSegmentSize = 64 << 20
+)
+
+// log record operations
+const (
+	// walPush payload: op | sequence number (uvarint) | data kind (1 byte) | element (see Codec.AppendElement)
+	walPush byte = iota + 1
+
+	// walPop payload: op | new head sequence number (uvarint) | data kind (1 byte)
+	// every element having a smaller sequence number is popped, the data kind survives RemoveAll
+	walPop
+
+	// walClear payload: op | new head sequence number (uvarint)
+	// works like walPop and also removes the data kind
+	walClear
+
+	// walBatch payload: op | record count (uvarint) | (payload length (uvarint) | payload of a record)...
+	// the records of a batch are replayed all together or, if the batch is torn, not at all
+	walBatch
+)
+
+// errTornRecord is returned by readWALRecord when the segment ends in the middle of a record
+var errTornRecord = errors.New("torn record")
+
+// walRecord a decoded log record
+type walRecord struct {
+	op   byte
+	seq  uint64
+	kind reflect.Kind
+	elem interface{}
+}
+
+// walSegment describes one log file, the segment file name is its index
+type walSegment struct {
+	index       uint64
+	size        int64
+	hasPush     bool
+	lastPushSeq uint64
+}
+
+// wal a write-ahead log split into segment files of a directory
+// records are only appended to the last (active) segment
+// every record is framed as payload length (uint32) | crc32 of the payload (uint32) | payload
+// the mutex guards the active segment against the background sync of SyncInterval
+// checkpoint (if set) returns the payload of the first record of every new segment,
+// so the segment still describes the queue once compaction deleted the segments before it
+type wal struct {
+	mu           sync.Mutex
+	dir          string
+	lock         *os.File
+	segments     []*walSegment
+	file         *os.File
+	checkpoint   func() []byte
+	syncPolicy   SyncPolicy
+	syncInterval time.Duration
+	segmentSize  int64
+	dirty        bool
+	closed       bool
+	syncErr      error
+	done         chan struct{}
+}
+
+// openWAL opens the log stored in dir (creating dir if needed) and returns the log and its records
+// the lock of dir is held until the log is closed, ErrLocked is returned if another log holds it
+// a torn record at the end of the last segment i.e. a write interrupted by a crash is truncated,
+// any other invalid record is reported as ErrCorruptedLog
+func openWAL(dir string, syncPolicy SyncPolicy, syncInterval time.Duration, segmentSize int64) (*wal, []walRecord, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+	lock, err := lockDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, records, err := readWAL(dir, syncPolicy, syncInterval, segmentSize)
+	if err != nil {
+		lock.Close()
+		return nil, nil, err
+	}
+	w.lock = lock
+	if syncPolicy == SyncInterval {
+		go w.syncLoop()
+	}
+	return w, records, nil
+}
+
+// readWAL reads the segments of dir and opens the last one for appending, the lock of dir must be held
+func readWAL(dir string, syncPolicy SyncPolicy, syncInterval time.Duration, segmentSize int64) (*wal, []walRecord, error) {
+	indexes, err := walSegmentIndexes(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(indexes) == 0 {
+		indexes = []uint64{0}
+	}
+
+	w := &wal{
+		dir:          dir,
+		syncPolicy:   syncPolicy,
+		syncInterval: syncInterval,
+		segmentSize:  segmentSize,
+		done:         make(chan struct{}),
+	}
+	records := make([]walRecord, 0)
+	for i, index := range indexes {
+		segment := &walSegment{index: index}
+		segmentRecords, err := w.readSegment(segment, i == len(indexes)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		w.segments = append(w.segments, segment)
+		records = append(records, segmentRecords...)
+	}
+
+	active := w.segments[len(w.segments)-1]
+	if w.file, err = os.OpenFile(w.segmentPath(active.index), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return nil, nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		w.file.Close()
+		return nil, nil, err
+	}
+	return w, records, nil
+}
+
+// walSegmentIndexes returns the indexes of the segment files in dir in ascending order
+func walSegmentIndexes(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]uint64, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, walSuffix) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, walSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+// readSegment reads every record of the segment and fills the segment description
+// a torn tail is truncated if the segment is the last one, otherwise it is reported as error
+// like a checksum mismatch or an unknown record, so the records following a corrupted one are never dropped
+func (w *wal) readSegment(segment *walSegment, last bool) ([]walRecord, error) {
+	path := w.segmentPath(segment.index)
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := make([]walRecord, 0)
+	reader := bufio.NewReader(file)
+	header := make([]byte, walHeaderSize)
+	for {
+		segmentRecords, size, err := readWALRecord(reader, header)
+		if err == io.EOF {
+			return records, nil
+		}
+		if errors.Is(err, errTornRecord) && last {
+			// torn tail write, drop it so the next record is appended after the last valid one
+			return records, os.Truncate(path, segment.size)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: segment %s at offset %d: %v", ErrCorruptedLog, path, segment.size, err)
+		}
+
+		for _, record := range segmentRecords {
+			if record.op == walPush {
+				segment.hasPush = true
+				segment.lastPushSeq = record.seq
+			}
+		}
+		segment.size += size
+		records = append(records, segmentRecords...)
+	}
+}
+
+// readWALRecord reads the next record and returns its operations (more than one for a batch) and its size
+// returns io.EOF if there is no more record and errTornRecord if the segment ends in the middle of the record
+// or the header is zero, which is what a crash leaves when the file size was updated before its content
+func readWALRecord(reader io.Reader, header []byte) ([]walRecord, int64, error) {
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, 0, fmt.Errorf("%w header", errTornRecord)
+		}
+		return nil, 0, err
+	}
+
+	length := binary.LittleEndian.Uint32(header)
+	if length == 0 {
+		return nil, 0, fmt.Errorf("%w zero length header", errTornRecord)
+	}
+	if length > walMaxRecordSize {
+		return nil, 0, errors.New("invalid record length")
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		if err == io.ErrUnexpectedEOF || err == io.EOF {
+			return nil, 0, fmt.Errorf("%w payload", errTornRecord)
+		}
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, 0, errors.New("record checksum mismatch")
+	}
+
+	if payload[0] != walBatch {
+		record, err := decodeWALRecord(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []walRecord{record}, int64(walHeaderSize + length), nil
+	}
+	records, err := decodeWALBatch(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	return records, int64(walHeaderSize + length), nil
+}
+
+// decodeWALBatch decodes the records of a walBatch payload, a batch can't hold another batch
+func decodeWALBatch(payload []byte) ([]walRecord, error) {
+	count, n := binary.Uvarint(payload[1:])
+	if n <= 0 || count > uint64(len(payload)) {
+		return nil, errors.New("invalid batch record count")
+	}
+	rest := payload[1+n:]
+
+	records := make([]walRecord, 0, count)
+	for i := uint64(0); i < count; i++ {
+		length, n := binary.Uvarint(rest)
+		if n <= 0 || length == 0 || length > uint64(len(rest)-n) {
+			return nil, errors.New("invalid batch record length")
+		}
+		recordPayload := rest[n : n+int(length)]
+		rest = rest[n+int(length):]
+		if recordPayload[0] == walBatch {
+			return nil, errors.New("nested batch record")
+		}
+		record, err := decodeWALRecord(recordPayload)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("unexpected bytes after batch records")
+	}
+	return records, nil
+}
+
+// decodeWALRecord decodes the payload of a single (non batch) record
+func decodeWALRecord(payload []byte) (walRecord, error) {
+	record := walRecord{op: payload[0]}
+	if record.op < walPush || record.op > walClear {
+		return walRecord{}, fmt.Errorf("unknown record operation %d", record.op)
+	}
+	seq, n := binary.Uvarint(payload[1:])
+	if n <= 0 {
+		return walRecord{}, errors.New("invalid record sequence number")
+	}
+	record.seq = seq
+	rest := payload[1+n:]
+
+	switch record.op {
+	case walPush:
+		if len(rest) < 1 {
+			return walRecord{}, errors.New("missing record data kind")
+		}
+		record.kind = reflect.Kind(rest[0])
+		elem, size, err := Codec.DecodeElement(record.kind, rest[1:])
+		if err != nil {
+			return walRecord{}, err
+		}
+		if size != len(rest)-1 {
+			return walRecord{}, errors.New("unexpected bytes after record element")
+		}
+		record.elem = elem
+	case walPop:
+		if len(rest) != 1 {
+			return walRecord{}, errors.New("invalid pop record")
+		}
+		record.kind = reflect.Kind(rest[0])
+	case walClear:
+		if len(rest) != 0 {
+			return walRecord{}, errors.New("invalid clear record")
+		}
+	}
+	return record, nil
+}
+
+// pushPayload encodes a walPush record payload
+func pushPayload(seq uint64, elem interface{}) ([]byte, error) {
+	payload := binary.AppendUvarint([]byte{walPush}, seq)
+	payload = append(payload, byte(reflect.TypeOf(elem).Kind()))
+	return Codec.AppendElement(payload, elem)
+}
+
+// popPayload encodes a walPop record payload
+func popPayload(head uint64, kind reflect.Kind) []byte {
+	payload := binary.AppendUvarint([]byte{walPop}, head)
+	return append(payload, byte(kind))
+}
+
+// clearPayload encodes a walClear record payload
+func clearPayload(head uint64) []byte {
+	return binary.AppendUvarint([]byte{walClear}, head)
+}
+
+// batchPayload encodes the payloads as a walBatch payload
+func batchPayload(payloads [][]byte) []byte {
+	payload := binary.AppendUvarint([]byte{walBatch}, uint64(len(payloads)))
+	for _, recordPayload := range payloads {
+		payload = binary.AppendUvarint(payload, uint64(len(recordPayload)))
+		payload = append(payload, recordPayload...)
+	}
+	return payload
+}
+
+// putRecordHeader writes the header of the record having the given payload to header
+func putRecordHeader(header, payload []byte) {
+	binary.LittleEndian.PutUint32(header, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.ChecksumIEEE(payload))
+}
+
+// append writes the payloads as a single record, a walBatch if there is more than one payload,
+// so a crash never leaves a part of them in the log, then flushes the record according to the sync policy
+// lastPushSeq is the sequence number of the last pushed element among the payloads (if hasPush)
+func (w *wal) append(payloads [][]byte, hasPush bool, lastPushSeq uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClosed
+	}
+	if err := w.syncErr; err != nil {
+		w.syncErr = nil
+		return err
+	}
+
+	active := w.segments[len(w.segments)-1]
+	if active.size >= w.segmentSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+		active = w.segments[len(w.segments)-1]
+	}
+
+	payload := payloads[0]
+	if len(payloads) > 1 {
+		payload = batchPayload(payloads)
+	}
+	if err := w.write(active, payload); err != nil {
+		return err
+	}
+	if hasPush {
+		active.hasPush = true
+		active.lastPushSeq = lastPushSeq
+	}
+
+	if w.syncPolicy == SyncAlways {
+		return w.sync()
+	}
+	return nil
+}
+
+// write appends the payload as a record to the active segment, the lock must be held
+func (w *wal) write(active *walSegment, payload []byte) error {
+	if len(payload) > walMaxRecordSize {
+		return fmt.Errorf("log record of %d bytes is bigger than %d bytes", len(payload), walMaxRecordSize)
+	}
+	buf := make([]byte, walHeaderSize, walHeaderSize+len(payload))
+	putRecordHeader(buf, payload)
+	buf = append(buf, payload...)
+	if _, err := w.file.Write(buf); err != nil {
+		// drop a partial write so the next record isn't appended after it
+		return errors.Join(err, w.file.Truncate(active.size))
+	}
+	active.size += int64(len(buf))
+	w.dirty = true
+	return nil
+}
+
+// syncLoop flushes the log once per sync interval until the log is closed
+// a failed flush is reported by the next append
+func (w *wal) syncLoop() {
+	ticker := time.NewTicker(w.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.closed {
+				w.mu.Unlock()
+				return
+			}
+			if err := w.sync(); err != nil {
+				w.syncErr = err
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// rotate closes the active segment and starts a new one beginning with the checkpoint record, the lock must be held
+// the checkpoint and the directory entry of the new segment are flushed regardless of the sync policy,
+// as compaction may delete every segment before it
+func (w *wal) rotate() error {
+	if err := w.sync(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	segment := &walSegment{index: w.segments[len(w.segments)-1].index + 1}
+	file, err := os.OpenFile(w.segmentPath(segment.index), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.segments = append(w.segments, segment)
+
+	if w.checkpoint != nil {
+		if err := w.write(segment, w.checkpoint()); err != nil {
+			return err
+		}
+		if err := w.sync(); err != nil {
+			return err
+		}
+	}
+	return syncDir(w.dir)
+}
+
+// compact deletes the leading segments whose every pushed element is popped i.e. has a sequence number below head
+// and flushes the directory if any was deleted, the active segment is never deleted
+func (w *wal) compact(head uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	removed := false
+	for len(w.segments) > 1 {
+		segment := w.segments[0]
+		if segment.hasPush && segment.lastPushSeq >= head {
+			break
+		}
+		if err := os.Remove(w.segmentPath(segment.index)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		w.segments = w.segments[1:]
+		removed = true
+	}
+	if !removed {
+		return nil
+	}
+	return syncDir(w.dir)
+}
+
+// flush flushes the active segment to stable storage
+func (w *wal) flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClosed
+	}
+	return w.sync()
+}
+
+// sync flushes the active segment if anything was written since the last flush, the lock must be held
+func (w *wal) sync() error {
+	if !w.dirty {
+		return nil
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.dirty = false
+	return nil
+}
+
+// close stops the background sync, flushes and closes the active segment and releases the lock of the directory
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	close(w.done)
+	defer w.lock.Close()
+
+	if err := w.sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// segmentPath returns the path of the segment file having the given index
+func (w *wal) segmentPath(index uint64) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%020d%s", index, walSuffix))
+}
@@ -0,0 +1,70 @@
+package Set
+
+import (
+	"fmt"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+)
+
+func (s *setStruct) MarshalBinary() ([]byte, error) {
+	setSlice := s.ToSlice()
+	Codec.Sort(setSlice)
+	return Codec.MarshalBinary(s.setDataKind, setSlice)
+}
+
+func (s *setStruct) UnmarshalBinary(data []byte) error {
+	kind, elems, err := Codec.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	// MarshalBinary writes the elements sorted, so an unsorted or a duplicate element can only be malformed data
+	if err := Codec.CheckSorted(elems); err != nil {
+		return err
+	}
+
+	decoded := Set()
+	decoded.key = s.key
+	decoded.setDataKind = kind
+	if err := decoded.Add(elems...); err != nil {
+		return err
+	}
+	if decoded.Len() != len(elems) {
+		return fmt.Errorf("%d elements have the same key", len(elems)-decoded.Len())
+	}
+
+	s.set = decoded.set
+	s.setDataKind = decoded.setDataKind
+	return nil
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (s *setStruct) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (s *setStruct) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+func (cs *concurrentSet) MarshalBinary() ([]byte, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.MarshalBinary()
+}
+
+func (cs *concurrentSet) UnmarshalBinary(data []byte) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.UnmarshalBinary(data)
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (cs *concurrentSet) GobEncode() ([]byte, error) {
+	return cs.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (cs *concurrentSet) GobDecode(data []byte) error {
+	return cs.UnmarshalBinary(data)
+}
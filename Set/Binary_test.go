@@ -0,0 +1,11 @@
+package Set
+
+import (
+	"testing"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/CodecTest"
+)
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	CodecTest.FuzzUnmarshalBinary(f, func() *setStruct { return Set() }, (*setStruct).Add)
+}
@@ -0,0 +1,183 @@
+package Set
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// NewConcurrent a global function which creates, initializes and returns a set instance
+// which is safe to use from multiple goroutines
+// it accepts the same Option values as Set()
+func NewConcurrent(opts ...Option) *concurrentSet {
+	return &concurrentSet{
+		s: Set(opts...),
+	}
+}
+
+// concurrentSet guards every setStruct method with a read write mutex
+// operations among several sets (Union, Intersection, IsSubSet, ...) work on snapshots
+// which are taken one set at a time, so two set locks are never held together
+// and calls like x.Union(y) and y.Union(x) running at the same time can't deadlock
+type concurrentSet struct {
+	mu sync.RWMutex
+	s  *setStruct
+}
+
+func (cs *concurrentSet) Add(elem ...interface{}) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.Add(elem...)
+}
+
+func (cs *concurrentSet) Remove(elem ...interface{}) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.s.Remove(elem...)
+}
+
+func (cs *concurrentSet) RemoveAll() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.s.RemoveAll()
+}
+
+func (cs *concurrentSet) Clear() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.s.Clear()
+}
+
+func (cs *concurrentSet) Copy() *concurrentSet {
+	return &concurrentSet{
+		s: cs.snapshot(),
+	}
+}
+
+func (cs *concurrentSet) Len() int {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.Len()
+}
+
+func (cs *concurrentSet) Union(sets ...*concurrentSet) (*concurrentSet, error) {
+	unionSet, err := cs.snapshot().Union(snapshots(sets)...)
+	if err != nil {
+		return nil, err
+	}
+	return &concurrentSet{s: unionSet}, nil
+}
+
+func (cs *concurrentSet) Intersection(sets ...*concurrentSet) (*concurrentSet, error) {
+	intersectionSet, err := cs.snapshot().Intersection(snapshots(sets)...)
+	if err != nil {
+		return nil, err
+	}
+	return &concurrentSet{s: intersectionSet}, nil
+}
+
+func (cs *concurrentSet) Difference(sets ...*concurrentSet) (*concurrentSet, error) {
+	diffSet, err := cs.snapshot().Difference(snapshots(sets)...)
+	if err != nil {
+		return nil, err
+	}
+	return &concurrentSet{s: diffSet}, nil
+}
+
+// UnionInPlace takes the snapshots of the parametric sets before locking the existing set
+func (cs *concurrentSet) UnionInPlace(sets ...*concurrentSet) error {
+	snapshotSets := snapshots(sets)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.UnionInPlace(snapshotSets...)
+}
+
+// IntersectionInPlace takes the snapshots of the parametric sets before locking the existing set
+func (cs *concurrentSet) IntersectionInPlace(sets ...*concurrentSet) error {
+	snapshotSets := snapshots(sets)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.IntersectionInPlace(snapshotSets...)
+}
+
+// DifferenceInPlace takes the snapshots of the parametric sets before locking the existing set
+func (cs *concurrentSet) DifferenceInPlace(sets ...*concurrentSet) error {
+	snapshotSets := snapshots(sets)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.DifferenceInPlace(snapshotSets...)
+}
+
+// MakeDisjoint modifies both sets, so it is the only method which locks two sets
+// the locks are always taken in the same (memory address) order to avoid deadlock
+func (cs *concurrentSet) MakeDisjoint(set *concurrentSet) error {
+	if cs == set {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		return cs.s.MakeDisjoint(set.s)
+	}
+
+	first, second := cs, set
+	if reflect.ValueOf(first).Pointer() > reflect.ValueOf(second).Pointer() {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+	return cs.s.MakeDisjoint(set.s)
+}
+
+func (cs *concurrentSet) MakeSubSet(elemNum int) (*concurrentSet, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	subSet, err := cs.s.MakeSubSet(elemNum)
+	if err != nil {
+		return nil, err
+	}
+	return &concurrentSet{s: subSet}, nil
+}
+
+func (cs *concurrentSet) Has(elem interface{}) bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.Has(elem)
+}
+
+func (cs *concurrentSet) IsDisjoint(set *concurrentSet) (bool, error) {
+	return cs.snapshot().IsDisjoint(set.snapshot())
+}
+
+func (cs *concurrentSet) IsSubSet(set *concurrentSet) (bool, error) {
+	return cs.snapshot().IsSubSet(set.snapshot())
+}
+
+func (cs *concurrentSet) IsSuperSet(set *concurrentSet) (bool, error) {
+	return cs.snapshot().IsSuperSet(set.snapshot())
+}
+
+func (cs *concurrentSet) ToSlice() []interface{} {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.ToSlice()
+}
+
+func (cs *concurrentSet) Display() {
+	fmt.Println(cs.ToSlice())
+}
+
+// snapshot returns a private copy of the set taken under the read lock
+func (cs *concurrentSet) snapshot() *setStruct {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.Copy()
+}
+
+// snapshots takes a snapshot of every set, one set at a time
+func snapshots(sets []*concurrentSet) []*setStruct {
+	snapshotSets := make([]*setStruct, len(sets))
+	for i, set := range sets {
+		snapshotSets[i] = set.snapshot()
+	}
+	return snapshotSets
+}
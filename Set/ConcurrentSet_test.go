@@ -0,0 +1,122 @@
+package Set
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentSetParallelAddRemove(t *testing.T) {
+	cs := NewConcurrent()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if err := cs.Add(g*500 + i); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%2 == 1 {
+					cs.Remove(g*500 + i)
+				}
+				_ = cs.Has(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if got := cs.Len(); got != 8*250 {
+		t.Fatalf("Len() = %d, want %d", got, 8*250)
+	}
+}
+
+// TestConcurrentSetCrossOperations runs x.Op(y) alongside y.Op(x), a lock order deadlock makes it time out
+func TestConcurrentSetCrossOperations(t *testing.T) {
+	x, y := NewConcurrent(), NewConcurrent()
+	for i := 0; i < 100; i++ {
+		_ = x.Add(i)
+		_ = y.Add(i + 50)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					if _, err := x.Union(y); err != nil {
+						t.Error(err)
+					}
+					if _, err := x.Intersection(y); err != nil {
+						t.Error(err)
+					}
+					_ = x.MakeDisjoint(y)
+					_ = x.Add(i)
+					_, _ = x.IsSubSet(y)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					if _, err := y.Union(x); err != nil {
+						t.Error(err)
+					}
+					if err := y.UnionInPlace(x); err != nil {
+						t.Error(err)
+					}
+					_ = y.MakeDisjoint(x)
+					_ = y.Add(i + 50)
+					_, _ = y.MakeSubSet(0)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("cross set operations deadlocked")
+	}
+}
+
+func TestConcurrentSetMakeDisjointSelf(t *testing.T) {
+	cs := NewConcurrent()
+	_ = cs.Add(1, 2, 3)
+	if err := cs.MakeDisjoint(cs); err != nil {
+		t.Fatal(err)
+	}
+	if got := cs.Len(); got != 0 {
+		t.Fatalf("Len() = %d after MakeDisjoint with itself, want 0", got)
+	}
+}
+
+func TestConcurrentSetParallelMakeSubSet(t *testing.T) {
+	cs := NewConcurrent()
+	for i := 0; i < 100; i++ {
+		_ = cs.Add(i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				subSet, err := cs.MakeSubSet(10)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if subSet.Len() != 10 {
+					t.Errorf("Len() = %d, want 10", subSet.Len())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
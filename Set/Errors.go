@@ -0,0 +1,34 @@
+package Set
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidElementNumber is returned by MakeSubSet when the element number
+	// is negative or bigger than the number of elements present in the set
+	ErrInvalidElementNumber = errors.New("invalid element number provided to make sub set")
+)
+
+// KindMismatchError is returned when an element's data kind differs from the data kind of the set
+// or when sets having different data kinds are combined
+type KindMismatchError struct {
+	Expected reflect.Kind
+	Got      reflect.Kind
+}
+
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("invalid value type: expected %v, got %v", e.Expected, e.Got)
+}
+
+// UnsupportedKindError is returned when an element's data kind can't be stored in a set
+// see Codec.UnsupportedKinds and the options which lift this restriction
+type UnsupportedKindError struct {
+	Kind reflect.Kind
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return fmt.Sprintf("%v is not supported type for set", e.Kind)
+}
@@ -0,0 +1,255 @@
+package Set
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+)
+
+// New a global function which creates, initializes and returns a type safe set instance
+// as every element has the same type T, set operations can't fail because of mismatched data types
+func New[T comparable]() *genericSet[T] {
+	return &genericSet[T]{
+		set: make(map[T]bool),
+	}
+}
+
+// genericSet where type safe set data are stored
+type genericSet[T comparable] struct {
+	set map[T]bool
+}
+
+// genericSetMethods stores interface declaration of all genericSet methods
+type genericSetMethods[T comparable] interface {
+	// Add adds one or more elements to an existing set
+	Add(elem ...T)
+
+	// Remove removes one or more elements from an existing set
+	Remove(elem ...T)
+
+	// RemoveAll removes all elements from the caller set
+	RemoveAll()
+
+	// Copy copies the existing set to a new set and returns the new set
+	Copy() *genericSet[T]
+
+	// Len returns the length of the existing set
+	Len() int
+
+	// Union performs the set union operation among the existing set and sets passed as params,
+	// stores data in a new set and returns the new set
+	Union(sets ...*genericSet[T]) *genericSet[T]
+
+	// Intersection performs the set intersection operation among the existing set and sets passed as params,
+	// stores data in a new set and returns the new set
+	Intersection(sets ...*genericSet[T]) *genericSet[T]
+
+	// Difference performs the set difference operation from the existing set and sets passed as params,
+	// stores data in a new set and returns the new set
+	// the set calling this method - parametric set1 - parametric set2 - parametric set3 -...
+	Difference(sets ...*genericSet[T]) *genericSet[T]
+
+	// UnionInPlace works like Union but stores the result in the existing set instead of a new set
+	UnionInPlace(sets ...*genericSet[T])
+
+	// IntersectionInPlace works like Intersection but stores the result in the existing set instead of a new set
+	IntersectionInPlace(sets ...*genericSet[T])
+
+	// DifferenceInPlace works like Difference but stores the result in the existing set instead of a new set
+	DifferenceInPlace(sets ...*genericSet[T])
+
+	// MakeDisjoint makes the caller set and parametric set disjoint to each other
+	// by removing their common elements from both sets
+	MakeDisjoint(set *genericSet[T])
+
+	// MakeSubSet creates and returns a sub set of the caller set having randomized elements equal to passed parameter
+	// elemNum < 0 or elemNum > number of elements present in the caller set is invalid choice
+	MakeSubSet(elemNum int) (*genericSet[T], error)
+
+	// Has checks whether the existing set has a specific element or not
+	Has(elem T) bool
+
+	// IsDisjoint checks whether the caller set and the parametric set are disjoint to each other or not
+	IsDisjoint(set *genericSet[T]) bool
+
+	// IsSubSet checks whether the caller set is a sub set of the parametric set
+	IsSubSet(set *genericSet[T]) bool
+
+	// IsSuperSet checks whether the caller set is a super set of the parametric set
+	IsSuperSet(set *genericSet[T]) bool
+
+	// ToSlice converts set to golang slice and return the slice
+	ToSlice() []T
+
+	// All returns an iterator over the elements of the set in no particular order
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so adding to or removing from the set inside the loop doesn't change what is yielded
+	All() iter.Seq[T]
+
+	// Display converts set to a golang slice and
+	// prints the converted set (slice) on console screen
+	Display()
+}
+
+func (s *genericSet[T]) Add(elem ...T) {
+	for _, e := range elem {
+		s.set[e] = true
+	}
+}
+
+func (s *genericSet[T]) Remove(elem ...T) {
+	for _, e := range elem {
+		delete(s.set, e)
+	}
+}
+
+func (s *genericSet[T]) RemoveAll() {
+	s.set = make(map[T]bool)
+}
+
+func (s *genericSet[T]) Copy() *genericSet[T] {
+	copySet := New[T]()
+	for elem := range s.set {
+		copySet.set[elem] = true
+	}
+	return copySet
+}
+
+func (s *genericSet[T]) Len() int {
+	return len(s.set)
+}
+
+func (s *genericSet[T]) Union(sets ...*genericSet[T]) *genericSet[T] {
+	unionSet := s.Copy()
+	for _, set := range sets {
+		for elem := range set.set {
+			unionSet.set[elem] = true
+		}
+	}
+	return unionSet
+}
+
+func (s *genericSet[T]) Intersection(sets ...*genericSet[T]) *genericSet[T] {
+	intersectionSet := New[T]()
+	for elem := range s.set {
+		inAll := true
+		for _, set := range sets {
+			if !set.set[elem] {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			intersectionSet.set[elem] = true
+		}
+	}
+	return intersectionSet
+}
+
+func (s *genericSet[T]) Difference(sets ...*genericSet[T]) *genericSet[T] {
+	diffSet := New[T]()
+	for elem := range s.set {
+		inAny := false
+		for _, set := range sets {
+			if set.set[elem] {
+				inAny = true
+				break
+			}
+		}
+		if !inAny {
+			diffSet.set[elem] = true
+		}
+	}
+	return diffSet
+}
+
+func (s *genericSet[T]) UnionInPlace(sets ...*genericSet[T]) {
+	for _, set := range sets {
+		for elem := range set.set {
+			s.set[elem] = true
+		}
+	}
+}
+
+func (s *genericSet[T]) IntersectionInPlace(sets ...*genericSet[T]) {
+	for elem := range s.set {
+		for _, set := range sets {
+			if !set.set[elem] {
+				delete(s.set, elem)
+				break
+			}
+		}
+	}
+}
+
+func (s *genericSet[T]) DifferenceInPlace(sets ...*genericSet[T]) {
+	for _, set := range sets {
+		for elem := range set.set {
+			delete(s.set, elem)
+		}
+	}
+}
+
+func (s *genericSet[T]) MakeDisjoint(set *genericSet[T]) {
+	for elem := range set.set {
+		if s.set[elem] {
+			s.Remove(elem)
+			set.Remove(elem)
+		}
+	}
+}
+
+func (s *genericSet[T]) MakeSubSet(elemNum int) (*genericSet[T], error) {
+	setSlice := s.ToSlice()
+	setSliceLen := len(setSlice)
+
+	subSet := New[T]()
+	if elemNum < 0 || elemNum > setSliceLen {
+		return subSet, ErrInvalidElementNumber
+	}
+
+	rand.Shuffle(setSliceLen, func(i, j int) { setSlice[i], setSlice[j] = setSlice[j], setSlice[i] })
+
+	for _, elem := range setSlice[:elemNum] {
+		subSet.set[elem] = true
+	}
+	return subSet, nil
+}
+
+func (s *genericSet[T]) Has(elem T) bool {
+	return s.set[elem]
+}
+
+func (s *genericSet[T]) IsDisjoint(set *genericSet[T]) bool {
+	for elem := range s.set {
+		if set.set[elem] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *genericSet[T]) IsSubSet(set *genericSet[T]) bool {
+	for elem := range s.set {
+		if !set.set[elem] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *genericSet[T]) IsSuperSet(set *genericSet[T]) bool {
+	return set.IsSubSet(s)
+}
+
+func (s *genericSet[T]) ToSlice() []T {
+	setSlice := make([]T, 0, len(s.set))
+	for elem := range s.set {
+		setSlice = append(setSlice, elem)
+	}
+	return setSlice
+}
+
+func (s *genericSet[T]) Display() {
+	fmt.Println(s.ToSlice())
+}
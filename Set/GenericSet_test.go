@@ -0,0 +1,109 @@
+package Set
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// genericIntSet returns a set holding the given ints
+func genericIntSet(elems ...int) *genericSet[int] {
+	s := New[int]()
+	s.Add(elems...)
+	return s
+}
+
+// genericSorted returns the elements of the set in ascending order
+func genericSorted(s *genericSet[int]) []int {
+	elems := s.ToSlice()
+	slices.Sort(elems)
+	return elems
+}
+
+func TestGenericSetAddRemove(t *testing.T) {
+	s := genericIntSet(3, 1, 2, 1)
+	if s.Len() != 3 || !s.Has(1) || s.Has(4) {
+		t.Fatalf("set %v, want [1 2 3]", genericSorted(s))
+	}
+	s.Remove(1, 4)
+	if got := genericSorted(s); !slices.Equal(got, []int{2, 3}) {
+		t.Fatalf("after Remove() set is %v, want [2 3]", got)
+	}
+	c := s.Copy()
+	c.Add(9)
+	s.RemoveAll()
+	if s.Len() != 0 || !slices.Equal(genericSorted(c), []int{2, 3, 9}) {
+		t.Fatalf("set %v and copy %v, want [] and [2 3 9]", genericSorted(s), genericSorted(c))
+	}
+}
+
+func TestGenericSetAlgebra(t *testing.T) {
+	x, y, z := genericIntSet(1, 2, 3, 4), genericIntSet(3, 4, 5), genericIntSet(4, 6)
+
+	tests := []struct {
+		name string
+		got  *genericSet[int]
+		want []int
+	}{
+		{"Union", x.Union(y, z), []int{1, 2, 3, 4, 5, 6}},
+		{"Intersection", x.Intersection(y, z), []int{4}},
+		{"Difference", x.Difference(y, z), []int{1, 2}},
+		{"Union of none", x.Union(), []int{1, 2, 3, 4}},
+		{"Intersection with empty", x.Intersection(New[int]()), []int{}},
+	}
+	for _, tt := range tests {
+		if got := genericSorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := genericSorted(x); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("set operations changed the receiver to %v", got)
+	}
+	if got := genericSorted(y); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Fatalf("set operations changed an argument to %v", got)
+	}
+
+	inPlace := x.Copy()
+	inPlace.UnionInPlace(y, z)
+	if got := genericSorted(inPlace); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("UnionInPlace = %v", got)
+	}
+	inPlace = x.Copy()
+	inPlace.IntersectionInPlace(y, z)
+	if got := genericSorted(inPlace); !slices.Equal(got, []int{4}) {
+		t.Fatalf("IntersectionInPlace = %v", got)
+	}
+	inPlace = x.Copy()
+	inPlace.DifferenceInPlace(y, z)
+	if got := genericSorted(inPlace); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("DifferenceInPlace = %v", got)
+	}
+}
+
+func TestGenericSetRelations(t *testing.T) {
+	x, y := genericIntSet(1, 2), genericIntSet(1, 2, 3)
+	if !x.IsSubSet(y) || y.IsSubSet(x) || !y.IsSuperSet(x) || x.IsSuperSet(y) {
+		t.Fatal("IsSubSet/IsSuperSet of {1 2} and {1 2 3} are wrong")
+	}
+	if x.IsDisjoint(y) || !x.IsDisjoint(genericIntSet(5)) {
+		t.Fatal("IsDisjoint is wrong")
+	}
+
+	y.MakeDisjoint(x)
+	if got := genericSorted(y); !slices.Equal(got, []int{3}) || x.Len() != 0 {
+		t.Fatalf("MakeDisjoint left %v and %v, want [3] and []", got, genericSorted(x))
+	}
+}
+
+func TestGenericSetMakeSubSet(t *testing.T) {
+	s := genericIntSet(1, 2, 3, 4)
+	sub, err := s.MakeSubSet(2)
+	if err != nil || sub.Len() != 2 || !sub.IsSubSet(s) {
+		t.Fatalf("MakeSubSet(2) = %v, %v, want 2 elements of the set", genericSorted(sub), err)
+	}
+	for _, n := range []int{-1, 5} {
+		if _, err := s.MakeSubSet(n); !errors.Is(err, ErrInvalidElementNumber) {
+			t.Fatalf("MakeSubSet(%d) = %v, want ErrInvalidElementNumber", n, err)
+		}
+	}
+}
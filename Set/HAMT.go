@@ -0,0 +1,342 @@
+package Set
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// hamtSeed is shared by every persistent set, so the same key has the same hash in every version
+// and tries of different versions can be compared node by node
+var hamtSeed = maphash.MakeSeed()
+
+const (
+	// hamtBits is the number of hash bits consumed by every level of the trie
+	hamtBits = 5
+
+	// hamtMask selects the hash bits of one level
+	hamtMask = 1<<hamtBits - 1
+)
+
+// hamtNode an inner node of a hash array mapped trie, it is never changed once created
+// bitmap has a bit set for every used slot of the level and children stores only the used slots in slot order,
+// a child is either a *hamtNode or a *hamtLeaf
+//
+// the trie is kept canonical: a node other than the root never holds a single leaf (the leaf takes its place),
+// so two tries having the same keys have the same shape whatever the order of the insertions and removals
+type hamtNode struct {
+	bitmap   uint32
+	children []interface{}
+}
+
+// hamtLeaf the keys having the same full hash and their elements
+// there is more than one key only when the 64 bits hashes collide
+type hamtLeaf struct {
+	hash  uint64
+	keys  []interface{}
+	elems []interface{}
+}
+
+// hamtHash returns the hash of a key, the key must be comparable
+// it is a variable so the tests can force hash collisions
+var hamtHash = func(key interface{}) uint64 {
+	return maphash.Comparable(hamtSeed, key)
+}
+
+// slot returns the bit of the slot of hash at the level starting at shift and its position in children
+func (n *hamtNode) slot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// child returns the child of the slot having the given bit or nil
+func (n *hamtNode) child(bit uint32) interface{} {
+	if n.bitmap&bit == 0 {
+		return nil
+	}
+	return n.children[bits.OnesCount32(n.bitmap&(bit-1))]
+}
+
+// find returns the element of key and whether the key is in the trie
+func (n *hamtNode) find(hash uint64, key interface{}, shift uint) (interface{}, bool) {
+	for {
+		bit, pos := n.slot(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+		switch child := n.children[pos].(type) {
+		case *hamtNode:
+			n = child
+			shift += hamtBits
+		case *hamtLeaf:
+			return child.find(hash, key)
+		}
+	}
+}
+
+// insert returns the node having key mapped to elem and whether the key is new
+// only the path to the key is copied, n itself is returned if nothing changes
+// the element of an existing key is replaced only if replace is true
+func (n *hamtNode) insert(hash uint64, key, elem interface{}, shift uint, replace bool) (*hamtNode, bool) {
+	bit, pos := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		leaf := &hamtLeaf{hash: hash, keys: []interface{}{key}, elems: []interface{}{elem}}
+		return n.withInserted(bit, pos, leaf), true
+	}
+
+	switch child := n.children[pos].(type) {
+	case *hamtNode:
+		newChild, added := child.insert(hash, key, elem, shift+hamtBits, replace)
+		if newChild == child {
+			return n, false
+		}
+		return n.withReplaced(pos, newChild), added
+	default:
+		leaf := child.(*hamtLeaf)
+		if leaf.hash == hash {
+			newLeaf, added := leaf.insert(key, elem, replace)
+			if newLeaf == leaf {
+				return n, false
+			}
+			return n.withReplaced(pos, newLeaf), added
+		}
+		newLeaf := &hamtLeaf{hash: hash, keys: []interface{}{key}, elems: []interface{}{elem}}
+		return n.withReplaced(pos, mergeLeaves(leaf, newLeaf, shift+hamtBits)), true
+	}
+}
+
+// remove returns what takes the place of the node once key is removed and whether the key was in the trie
+// the result is nil if nothing is left, a *hamtLeaf if a single leaf is left (so the parent can inline it)
+// or a *hamtNode, n itself is returned if the key isn't in the trie
+func (n *hamtNode) remove(hash uint64, key interface{}, shift uint) (interface{}, bool) {
+	bit, pos := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	var newChild interface{}
+	var removed bool
+	switch child := n.children[pos].(type) {
+	case *hamtNode:
+		newChild, removed = child.remove(hash, key, shift+hamtBits)
+	case *hamtLeaf:
+		newChild, removed = child.remove(hash, key)
+	}
+	if !removed {
+		return n, false
+	}
+
+	if newChild == nil {
+		if len(n.children) == 1 {
+			return nil, true
+		}
+		newNode := n.withRemoved(bit, pos)
+		if leaf, isLeaf := newNode.children[0].(*hamtLeaf); isLeaf && len(newNode.children) == 1 {
+			return leaf, true
+		}
+		return newNode, true
+	}
+	if leaf, isLeaf := newChild.(*hamtLeaf); isLeaf && len(n.children) == 1 {
+		return leaf, true
+	}
+	return n.withReplaced(pos, newChild), true
+}
+
+// withInserted returns a copy of the node having child in the free slot bit at position pos
+func (n *hamtNode) withInserted(bit uint32, pos int, child interface{}) *hamtNode {
+	children := make([]interface{}, len(n.children)+1)
+	copy(children, n.children[:pos])
+	children[pos] = child
+	copy(children[pos+1:], n.children[pos:])
+	return &hamtNode{bitmap: n.bitmap | bit, children: children}
+}
+
+// withReplaced returns a copy of the node having child at position pos
+func (n *hamtNode) withReplaced(pos int, child interface{}) *hamtNode {
+	children := make([]interface{}, len(n.children))
+	copy(children, n.children)
+	children[pos] = child
+	return &hamtNode{bitmap: n.bitmap, children: children}
+}
+
+// withRemoved returns a copy of the node without the child of the slot bit at position pos
+func (n *hamtNode) withRemoved(bit uint32, pos int) *hamtNode {
+	children := make([]interface{}, 0, len(n.children)-1)
+	children = append(children, n.children[:pos]...)
+	children = append(children, n.children[pos+1:]...)
+	return &hamtNode{bitmap: n.bitmap &^ bit, children: children}
+}
+
+// mergeLeaves returns the node holding two leaves of different hashes at the level starting at shift
+// the leaves go one level deeper as long as their slots are the same
+func mergeLeaves(a, b *hamtLeaf, shift uint) *hamtNode {
+	slotA, slotB := (a.hash>>shift)&hamtMask, (b.hash>>shift)&hamtMask
+	if slotA == slotB {
+		return &hamtNode{bitmap: 1 << slotA, children: []interface{}{mergeLeaves(a, b, shift+hamtBits)}}
+	}
+	if slotA > slotB {
+		a, b = b, a
+		slotA, slotB = slotB, slotA
+	}
+	return &hamtNode{bitmap: 1<<slotA | 1<<slotB, children: []interface{}{a, b}}
+}
+
+// find returns the element of key and whether the key is in the leaf
+func (l *hamtLeaf) find(hash uint64, key interface{}) (interface{}, bool) {
+	if l.hash != hash {
+		return nil, false
+	}
+	for i, k := range l.keys {
+		if k == key {
+			return l.elems[i], true
+		}
+	}
+	return nil, false
+}
+
+// insert returns the leaf having key mapped to elem and whether the key is new, see hamtNode.insert
+func (l *hamtLeaf) insert(key, elem interface{}, replace bool) (*hamtLeaf, bool) {
+	for i, k := range l.keys {
+		if k != key {
+			continue
+		}
+		if !replace {
+			return l, false
+		}
+		elems := append([]interface{}(nil), l.elems...)
+		elems[i] = elem
+		return &hamtLeaf{hash: l.hash, keys: l.keys, elems: elems}, false
+	}
+
+	return &hamtLeaf{
+		hash:  l.hash,
+		keys:  append(append([]interface{}(nil), l.keys...), key),
+		elems: append(append([]interface{}(nil), l.elems...), elem),
+	}, true
+}
+
+// remove returns the leaf without key (nil if nothing is left) and whether the key was in the leaf
+func (l *hamtLeaf) remove(hash uint64, key interface{}) (interface{}, bool) {
+	if l.hash != hash {
+		return l, false
+	}
+	for i, k := range l.keys {
+		if k != key {
+			continue
+		}
+		if len(l.keys) == 1 {
+			return nil, true
+		}
+		leaf := &hamtLeaf{hash: l.hash}
+		leaf.keys = append(append(leaf.keys, l.keys[:i]...), l.keys[i+1:]...)
+		leaf.elems = append(append(leaf.elems, l.elems[:i]...), l.elems[i+1:]...)
+		return leaf, true
+	}
+	return l, false
+}
+
+// hamtEqual reports whether two entries (nodes or leaves) hold the same keys
+// the tries are canonical so different shapes mean different keys, and shared entries are equal without a walk
+func hamtEqual(a, b interface{}) bool {
+	if a == b {
+		return true
+	}
+
+	switch x := a.(type) {
+	case *hamtNode:
+		y, isNode := b.(*hamtNode)
+		if !isNode || x.bitmap != y.bitmap {
+			return false
+		}
+		for i := range x.children {
+			if !hamtEqual(x.children[i], y.children[i]) {
+				return false
+			}
+		}
+		return true
+	case *hamtLeaf:
+		y, isLeaf := b.(*hamtLeaf)
+		if !isLeaf || x.hash != y.hash || len(x.keys) != len(y.keys) {
+			return false
+		}
+		for _, key := range x.keys {
+			if _, has := y.find(y.hash, key); !has {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// hamtDiff appends the elements of the entry b missing from the entry a to added and
+// the elements of a missing from b to removed, an entry is a node, a leaf or nil
+// entries shared by both tries are skipped without a walk
+func hamtDiff(a, b interface{}, added, removed *[]interface{}) {
+	if a == b {
+		return
+	}
+
+	nodeA, isNodeA := a.(*hamtNode)
+	nodeB, isNodeB := b.(*hamtNode)
+	if isNodeA && isNodeB {
+		for slot := 0; slot <= hamtMask; slot++ {
+			bit := uint32(1) << slot
+			hamtDiff(nodeA.child(bit), nodeB.child(bit), added, removed)
+		}
+		return
+	}
+
+	// at least one side is a leaf or nil, i.e. it holds few keys
+	keysA, elemsA := hamtCollect(a, nil, nil)
+	keysB, elemsB := hamtCollect(b, nil, nil)
+	inA := make(map[interface{}]bool, len(keysA))
+	for _, key := range keysA {
+		inA[key] = true
+	}
+	inB := make(map[interface{}]bool, len(keysB))
+	for i, key := range keysB {
+		inB[key] = true
+		if !inA[key] {
+			*added = append(*added, elemsB[i])
+		}
+	}
+	for i, key := range keysA {
+		if !inB[key] {
+			*removed = append(*removed, elemsA[i])
+		}
+	}
+}
+
+// hamtCollect appends the keys and the elements of the entry to keys and elems
+func hamtCollect(entry interface{}, keys, elems []interface{}) ([]interface{}, []interface{}) {
+	switch x := entry.(type) {
+	case *hamtNode:
+		for _, child := range x.children {
+			keys, elems = hamtCollect(child, keys, elems)
+		}
+	case *hamtLeaf:
+		keys = append(keys, x.keys...)
+		elems = append(elems, x.elems...)
+	}
+	return keys, elems
+}
+
+// hamtWalk calls f for every key and element of the entry until f returns false
+// returns false if the walk was stopped
+func hamtWalk(entry interface{}, f func(key, elem interface{}) bool) bool {
+	switch x := entry.(type) {
+	case *hamtNode:
+		for _, child := range x.children {
+			if !hamtWalk(child, f) {
+				return false
+			}
+		}
+	case *hamtLeaf:
+		for i, key := range x.keys {
+			if !f(key, x.elems[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
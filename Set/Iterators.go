@@ -0,0 +1,25 @@
+package Set
+
+import (
+	"iter"
+	"slices"
+)
+
+func (s *setStruct) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		slices.Values(s.ToSlice())(yield)
+	}
+}
+
+func (s *genericSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		slices.Values(s.ToSlice())(yield)
+	}
+}
+
+// All iterates over a snapshot taken under the read lock, see setStruct.All
+func (cs *concurrentSet) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		slices.Values(cs.ToSlice())(yield)
+	}
+}
@@ -0,0 +1,34 @@
+package Set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetAll(t *testing.T) {
+	s := intSet(1, 2, 3)
+	var elems []int
+	for elem := range s.All() {
+		elems = append(elems, elem.(int))
+		// the loop walks a snapshot
+		s.Remove(elem)
+		_ = s.Add(elem.(int) + 10)
+	}
+	slices.Sort(elems)
+	if !slices.Equal(elems, []int{1, 2, 3}) {
+		t.Fatalf("All() yielded %v, want 1, 2 and 3", elems)
+	}
+
+	count := 0
+	for range New[int]().All() {
+		count++
+	}
+	g := genericIntSet(1, 2, 3)
+	for range g.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("an empty set and a break ran %d iterations, want 1", count)
+	}
+}
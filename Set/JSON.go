@@ -0,0 +1,39 @@
+package Set
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Codec"
+
+func (s *setStruct) MarshalJSON() ([]byte, error) {
+	setSlice := s.ToSlice()
+	Codec.Sort(setSlice)
+	return Codec.MarshalJSON(s.setDataKind, setSlice)
+}
+
+func (s *setStruct) UnmarshalJSON(data []byte) error {
+	kind, elems, err := Codec.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	decoded := Set()
+	decoded.key = s.key
+	decoded.setDataKind = kind
+	if err := decoded.Add(elems...); err != nil {
+		return err
+	}
+
+	s.set = decoded.set
+	s.setDataKind = decoded.setDataKind
+	return nil
+}
+
+func (cs *concurrentSet) MarshalJSON() ([]byte, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.MarshalJSON()
+}
+
+func (cs *concurrentSet) UnmarshalJSON(data []byte) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.s.UnmarshalJSON(data)
+}
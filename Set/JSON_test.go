@@ -0,0 +1,108 @@
+package Set
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSetJSONRoundTrip(t *testing.T) {
+	s := Set()
+	_ = s.Add(3, 1, 2)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"int","elements":[1,2,3]}` {
+		t.Fatalf("Marshal() = %s, want the elements sorted", data)
+	}
+
+	decoded := Set()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Len() != 3 || !decoded.Has(1) || !decoded.Has(2) || !decoded.Has(3) || decoded.setDataKind != reflect.Int {
+		t.Fatalf("decoded %v of kind %v, want {1 2 3} of kind int", decoded.ToSlice(), decoded.setDataKind)
+	}
+}
+
+func TestSetJSONEmptyAndCleared(t *testing.T) {
+	s := Set()
+	_ = s.Add("a")
+	s.RemoveAll()
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"string","elements":[]}` {
+		t.Fatalf("Marshal() of an emptied set = %s", data)
+	}
+	decoded := Set()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Len() != 0 || decoded.setDataKind != reflect.String {
+		t.Fatalf("decoded size %d and kind %v, want an empty string set", decoded.Len(), decoded.setDataKind)
+	}
+
+	s.Clear()
+	if data, err = json.Marshal(s); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"","elements":[]}` {
+		t.Fatalf("Marshal() of a cleared set = %s", data)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.setDataKind != reflect.Invalid {
+		t.Fatalf("decoded kind %v, want no kind", decoded.setDataKind)
+	}
+}
+
+func TestSetJSONNamedType(t *testing.T) {
+	type ID int
+	s := Set()
+	_ = s.Add(ID(7))
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Set()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Has(7) || decoded.Has(ID(7)) {
+		t.Fatalf("decoded %#v, want int 7", decoded.ToSlice())
+	}
+}
+
+func TestSetJSONRejectsStructs(t *testing.T) {
+	type point struct{ X, Y int }
+	s := Set()
+	_ = s.Add(point{1, 2})
+	if _, err := json.Marshal(s); err == nil {
+		t.Fatal("Marshal() of a struct set succeeded, the document couldn't be decoded")
+	}
+}
+
+func TestSetJSONRejectsBadInput(t *testing.T) {
+	inputs := []string{
+		`not json`,
+		`{"kind":"int","elements":["a"]}`,
+		`{"kind":"int","elements":[1.5]}`,
+		`{"kind":"int8","elements":[300]}`,
+		`{"kind":"struct","elements":[]}`,
+		`{"kind":"","elements":[1]}`,
+	}
+	for _, input := range inputs {
+		s := Set()
+		_ = s.Add(1, 2)
+		if err := json.Unmarshal([]byte(input), s); err == nil {
+			t.Fatalf("Unmarshal(%s) succeeded", input)
+		}
+		if s.Len() != 2 || !s.Has(1) || !s.Has(2) || s.setDataKind != reflect.Int {
+			t.Fatalf("a failed Unmarshal(%s) changed the set to %v of kind %v", input, s.ToSlice(), s.setDataKind)
+		}
+	}
+}
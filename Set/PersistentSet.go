@@ -0,0 +1,333 @@
+package Set
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// Persistent a global function which creates, initializes and returns an empty persistent set instance
+// it accepts the same Option values as Set() and follows the same data kind rules
+func Persistent(opts ...Option) *persistentSet {
+	s := Set(opts...)
+	return &persistentSet{
+		key: s.key,
+	}
+}
+
+// persistentSet an immutable set stored as a hash array mapped trie (see hamtNode) keyed like setStruct
+// every change returns a new set copying only the path to the changed key, the rest of the trie is shared,
+// so keeping old versions is cheap and comparing versions skips the shared parts
+// it is safe to use from multiple goroutines as nothing is ever modified
+type persistentSet struct {
+	root        *hamtNode
+	size        int
+	setDataKind reflect.Kind
+	key         func(elem interface{}) interface{}
+}
+
+// persistentSetMethods stores interface declaration of all persistentSet methods
+type persistentSetMethods interface {
+	// Add returns a new set having the elements added to the caller set
+	// returns error if data types mismatched, the caller set is never changed
+	Add(elem ...interface{}) (*persistentSet, error)
+
+	// Remove returns a new set without the elements
+	Remove(elem ...interface{}) *persistentSet
+
+	// RemoveAll returns an empty set keeping the data type of the caller set
+	RemoveAll() *persistentSet
+
+	// Clear returns an empty set without a data type
+	Clear() *persistentSet
+
+	// Len returns the length of the set in O(1)
+	Len() int
+
+	// Has checks whether the set has a specific element or not
+	Has(elem interface{}) bool
+
+	// Union returns a new set having the elements of the caller set and the parametric sets
+	// the new set shares the trie of the caller set
+	// returns error if the data types of the sets mismatched
+	Union(sets ...*persistentSet) (*persistentSet, error)
+
+	// Intersection returns a new set having the elements of the caller set which are in every parametric set
+	// returns error if the data types of the sets mismatched
+	Intersection(sets ...*persistentSet) (*persistentSet, error)
+
+	// Difference returns a new set having the elements of the caller set which are in none of the parametric sets
+	// returns error if the data types of the sets mismatched
+	Difference(sets ...*persistentSet) (*persistentSet, error)
+
+	// Equal checks whether the set has the same elements as the parametric set
+	// the parts of the tries shared by both versions are not compared
+	Equal(set *persistentSet) bool
+
+	// Diff returns the elements of the parametric set missing from the caller set (added)
+	// and the elements of the caller set missing from the parametric set (removed)
+	// the parts of the tries shared by both versions are skipped, so diffing close versions is cheap
+	Diff(set *persistentSet) (added, removed []interface{})
+
+	// ToSlice converts set to golang slice and return the slice
+	ToSlice() []interface{}
+
+	// Display prints the set as slice on console screen
+	Display()
+
+	// All returns an iterator over the elements of the set, the order is unspecified
+	All() iter.Seq[interface{}]
+
+	// ToSet returns a mutable set having the same elements, data type and key function
+	ToSet() *setStruct
+
+	// private methods (for internal use only)
+
+	// with returns a copy of the set header, the trie is shared
+	with(root *hamtNode, size int) *persistentSet
+
+	// insert adds elem to the set header in place, only used on fresh headers
+	insert(elem interface{})
+
+	// delete removes elem from the set header in place, only used on fresh headers
+	delete(elem interface{})
+
+	// entry returns the root as a trie entry, nil if the set is empty
+	entry() interface{}
+
+	// probe returns a setStruct having the data kind and key function of the set, used to check data kinds
+	probe() *setStruct
+}
+
+func (ps *persistentSet) Add(elem ...interface{}) (*persistentSet, error) {
+	probe := ps.probe()
+	for _, e := range elem {
+		if err := probe.checkDataKind(e); err != nil {
+			return nil, err
+		}
+	}
+
+	added := ps.with(ps.root, ps.size)
+	added.setDataKind = probe.setDataKind
+	for _, e := range elem {
+		added.insert(e)
+	}
+	return added, nil
+}
+
+func (ps *persistentSet) Remove(elem ...interface{}) *persistentSet {
+	removed := ps.with(ps.root, ps.size)
+	for _, e := range elem {
+		removed.delete(e)
+	}
+	return removed
+}
+
+func (ps *persistentSet) RemoveAll() *persistentSet {
+	return ps.with(nil, 0)
+}
+
+func (ps *persistentSet) Clear() *persistentSet {
+	return &persistentSet{key: ps.key}
+}
+
+func (ps *persistentSet) Len() int {
+	return ps.size
+}
+
+func (ps *persistentSet) Has(elem interface{}) bool {
+	if ps.root == nil {
+		return false
+	}
+	key := ps.probe().keyOf(elem)
+	_, has := ps.root.find(hamtHash(key), key, 0)
+	return has
+}
+
+func (ps *persistentSet) Union(sets ...*persistentSet) (*persistentSet, error) {
+	if err := ps.checkSetsKind(sets); err != nil {
+		return nil, err
+	}
+
+	unionSet := ps.with(ps.root, ps.size)
+	for _, set := range sets {
+		if unionSet.setDataKind == reflect.Invalid {
+			unionSet.setDataKind = set.setDataKind
+		}
+		if set.root == unionSet.root {
+			continue
+		}
+		for elem := range set.All() {
+			unionSet.insert(elem)
+		}
+	}
+	return unionSet, nil
+}
+
+func (ps *persistentSet) Intersection(sets ...*persistentSet) (*persistentSet, error) {
+	if err := ps.checkSetsKind(sets); err != nil {
+		return nil, err
+	}
+
+	intersectionSet := ps.with(ps.root, ps.size)
+	for _, set := range sets {
+		if intersectionSet.setDataKind == reflect.Invalid {
+			intersectionSet.setDataKind = set.setDataKind
+		}
+		if set.root == ps.root {
+			continue
+		}
+		for elem := range ps.All() {
+			if !set.Has(elem) {
+				intersectionSet.delete(elem)
+			}
+		}
+	}
+	return intersectionSet, nil
+}
+
+func (ps *persistentSet) Difference(sets ...*persistentSet) (*persistentSet, error) {
+	if err := ps.checkSetsKind(sets); err != nil {
+		return nil, err
+	}
+
+	diffSet := ps.with(ps.root, ps.size)
+	for _, set := range sets {
+		if set.root == ps.root {
+			return diffSet.with(nil, 0), nil
+		}
+		for elem := range set.All() {
+			diffSet.delete(elem)
+		}
+	}
+	return diffSet, nil
+}
+
+func (ps *persistentSet) Equal(set *persistentSet) bool {
+	if ps.size != set.size {
+		return false
+	}
+	return hamtEqual(ps.entry(), set.entry())
+}
+
+func (ps *persistentSet) Diff(set *persistentSet) (added, removed []interface{}) {
+	added, removed = make([]interface{}, 0), make([]interface{}, 0)
+	hamtDiff(ps.entry(), set.entry(), &added, &removed)
+	return added, removed
+}
+
+func (ps *persistentSet) ToSlice() []interface{} {
+	setSlice := make([]interface{}, 0, ps.size)
+	for elem := range ps.All() {
+		setSlice = append(setSlice, elem)
+	}
+	return setSlice
+}
+
+func (ps *persistentSet) Display() {
+	fmt.Println(ps.ToSlice())
+}
+
+func (ps *persistentSet) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		hamtWalk(ps.entry(), func(_, elem interface{}) bool {
+			return yield(elem)
+		})
+	}
+}
+
+func (ps *persistentSet) ToSet() *setStruct {
+	s := &setStruct{
+		set:         make(map[interface{}]interface{}, ps.size),
+		setDataKind: ps.setDataKind,
+		key:         ps.key,
+	}
+	hamtWalk(ps.entry(), func(key, elem interface{}) bool {
+		s.set[key] = elem
+		return true
+	})
+	return s
+}
+
+// ToPersistent builds the trie in O(n), nothing is shared with the caller set
+func (s *setStruct) ToPersistent() *persistentSet {
+	ps := &persistentSet{setDataKind: s.setDataKind, key: s.key}
+	for _, elem := range s.set {
+		ps.insert(elem)
+	}
+	return ps
+}
+
+// ToPersistent takes the snapshot under the read lock
+func (cs *concurrentSet) ToPersistent() *persistentSet {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.s.ToPersistent()
+}
+
+func (ps *persistentSet) with(root *hamtNode, size int) *persistentSet {
+	return &persistentSet{
+		root:        root,
+		size:        size,
+		setDataKind: ps.setDataKind,
+		key:         ps.key,
+	}
+}
+
+func (ps *persistentSet) insert(elem interface{}) {
+	key := ps.probe().keyOf(elem)
+	root := ps.root
+	if root == nil {
+		root = &hamtNode{}
+	}
+	// an element having the same key replaces the old one like setStruct.Add,
+	// without a key function the element is the key so there is nothing to replace
+	root, added := root.insert(hamtHash(key), key, elem, 0, ps.key != nil)
+	ps.root = root
+	if added {
+		ps.size++
+	}
+}
+
+func (ps *persistentSet) delete(elem interface{}) {
+	if ps.root == nil {
+		return
+	}
+
+	key := ps.probe().keyOf(elem)
+	hash := hamtHash(key)
+	entry, removed := ps.root.remove(hash, key, 0)
+	if !removed {
+		return
+	}
+	ps.size--
+	switch root := entry.(type) {
+	case *hamtNode:
+		ps.root = root
+	case *hamtLeaf:
+		// the root is always a node, a single leaf goes back to its slot of the first level
+		ps.root = (&hamtNode{}).withInserted(uint32(1)<<(root.hash&hamtMask), 0, root)
+	default:
+		ps.root = nil
+	}
+}
+
+func (ps *persistentSet) entry() interface{} {
+	if ps.root == nil {
+		return nil
+	}
+	return ps.root
+}
+
+func (ps *persistentSet) probe() *setStruct {
+	return &setStruct{setDataKind: ps.setDataKind, key: ps.key}
+}
+
+// checkSetsKind checks the data kinds of the sets like setStruct.checkSetsKind
+func (ps *persistentSet) checkSetsKind(sets []*persistentSet) error {
+	probes := make([]*setStruct, len(sets))
+	for i, set := range sets {
+		probes[i] = set.probe()
+	}
+	return ps.probe().checkSetsKind(probes)
+}
@@ -0,0 +1,151 @@
+package Set
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// collidingHash sends the keys to 21 hashes only, the hashes having the same low bits differ in their
+// top bits, so the leaves are merged down to the deepest levels and full hash collisions are common
+func collidingHash(key interface{}) uint64 {
+	k := uint64(key.(int))
+	return k%7<<57 | k%3
+}
+
+// withHash makes the persistent sets use hash until the end of the test
+func withHash(t *testing.T, hash func(key interface{}) uint64) {
+	saved := hamtHash
+	hamtHash = hash
+	t.Cleanup(func() { hamtHash = saved })
+}
+
+// sortedInts returns the int elements sorted
+func sortedInts(elems []interface{}) []int {
+	ints := make([]int, len(elems))
+	for i, elem := range elems {
+		ints[i] = elem.(int)
+	}
+	sort.Ints(ints)
+	return ints
+}
+
+// checkAgainstModel checks that the persistent set has exactly the elements of the model set
+func checkAgainstModel(t *testing.T, step int, ps *persistentSet, model *setStruct, keys int) {
+	t.Helper()
+	if ps.Len() != model.Len() {
+		t.Fatalf("step %d: Len() = %d, want %d", step, ps.Len(), model.Len())
+	}
+	for k := 0; k < keys; k++ {
+		if ps.Has(k) != model.Has(k) {
+			t.Fatalf("step %d: Has(%d) = %t, want %t", step, k, ps.Has(k), model.Has(k))
+		}
+	}
+	if got, want := sortedInts(ps.ToSlice()), sortedInts(model.ToSlice()); !slices.Equal(got, want) {
+		t.Fatalf("step %d: set is %v, want %v", step, got, want)
+	}
+}
+
+// difference returns the elements of a missing from b, sorted
+func difference(a, b *setStruct) []int {
+	diff, _ := a.Difference(b)
+	return sortedInts(diff.ToSlice())
+}
+
+func testPersistentSetMatchesSet(t *testing.T, keys int) {
+	rng := rand.New(rand.NewSource(1))
+	ps, model := Persistent(), Set()
+	versions, models := []*persistentSet{ps}, []*setStruct{model.Copy()}
+	for step := 0; step < 3000; step++ {
+		elems := make([]interface{}, rng.Intn(4)+1)
+		for i := range elems {
+			elems[i] = rng.Intn(keys)
+		}
+		if rng.Intn(3) == 0 {
+			ps = ps.Remove(elems...)
+			model.Remove(elems...)
+		} else {
+			var err error
+			if ps, err = ps.Add(elems...); err != nil {
+				t.Fatal(err)
+			}
+			_ = model.Add(elems...)
+		}
+		checkAgainstModel(t, step, ps, model, keys)
+
+		// an old version is left as it was
+		old := rng.Intn(len(versions))
+		checkAgainstModel(t, step, versions[old], models[old], keys)
+
+		// Diff against an old version is the difference of the models both ways
+		added, removed := versions[old].Diff(ps)
+		if got, want := sortedInts(added), difference(model, models[old]); !slices.Equal(got, want) {
+			t.Fatalf("step %d: Diff added %v, want %v", step, got, want)
+		}
+		if got, want := sortedInts(removed), difference(models[old], model); !slices.Equal(got, want) {
+			t.Fatalf("step %d: Diff removed %v, want %v", step, got, want)
+		}
+		if got, want := versions[old].Equal(ps), len(added) == 0 && len(removed) == 0; got != want {
+			t.Fatalf("step %d: Equal() = %t, want %t", step, got, want)
+		}
+
+		// a set built in another order shares nothing with ps but is still equal
+		shuffled := model.ToSlice()
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		rebuilt, _ := Persistent().Add(shuffled...)
+		if !rebuilt.Equal(ps) || !ps.Equal(rebuilt) {
+			t.Fatalf("step %d: a set rebuilt in another order isn't Equal", step)
+		}
+		if added, removed := ps.Diff(rebuilt); len(added) != 0 || len(removed) != 0 {
+			t.Fatalf("step %d: Diff with a rebuilt set = %v, %v, want nothing", step, added, removed)
+		}
+		if len(shuffled) > 0 {
+			if smaller := rebuilt.Remove(shuffled[0]); smaller.Equal(ps) {
+				t.Fatalf("step %d: a set missing %v is Equal", step, shuffled[0])
+			}
+		}
+
+		if step%50 == 0 {
+			versions, models = append(versions, ps), append(models, model.Copy())
+		}
+	}
+}
+
+func TestPersistentSetMatchesSet(t *testing.T) {
+	testPersistentSetMatchesSet(t, 64)
+}
+
+func TestPersistentSetMatchesSetWithCollisions(t *testing.T) {
+	withHash(t, collidingHash)
+	testPersistentSetMatchesSet(t, 64)
+}
+
+func TestPersistentSetCollisionLeaf(t *testing.T) {
+	withHash(t, func(interface{}) uint64 { return 42 })
+	ps, _ := Persistent().Add(1, 2, 3)
+	if ps.Len() != 3 || !ps.Has(1) || !ps.Has(2) || !ps.Has(3) || ps.Has(4) {
+		t.Fatalf("a leaf of colliding keys holds %v", ps.ToSlice())
+	}
+
+	// removing down to a single key leaves the same trie as adding it alone
+	single, _ := Persistent().Add(2)
+	if shrunk := ps.Remove(1, 3); !shrunk.Equal(single) || !hamtEqual(shrunk.entry(), single.entry()) {
+		t.Fatalf("shrunk leaf %v isn't Equal to %v", shrunk.ToSlice(), single.ToSlice())
+	}
+	other, _ := Persistent().Add(3, 1, 2)
+	if !ps.Equal(other) {
+		t.Fatal("colliding keys added in another order aren't Equal")
+	}
+	replaced, _ := Persistent().Add(1, 2, 4)
+	if ps.Equal(replaced) {
+		t.Fatal("leaves of the same hash and size but different keys are Equal")
+	}
+	added, removed := ps.Diff(replaced)
+	if !slices.Equal(sortedInts(added), []int{4}) || !slices.Equal(sortedInts(removed), []int{3}) {
+		t.Fatalf("Diff() = %v, %v, want [4], [3]", added, removed)
+	}
+	if empty := ps.Remove(1, 2, 3); empty.Len() != 0 || empty.root != nil {
+		t.Fatalf("removing every colliding key left %v", empty.ToSlice())
+	}
+}
@@ -1,34 +1,40 @@
 package Set
 
 import (
-	"errors"
 	"fmt"
+	"iter"
 	"math/rand"
 	"reflect"
 	"time"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
 )
 
 // Set a global function which creates, initializes and returns a set instance
-func Set() *setStruct {
-	return &setStruct{
-		set: make(map[interface{}]bool),
+// the data type is checked at runtime, use New for a type safe set
+// optional Option values (e.g. WithKey) can be passed to configure the set
+func Set(opts ...Option) *setStruct {
+	s := &setStruct{
+		set: make(map[interface{}]interface{}),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// not supported data kinds are stored here
-var (
-	invalidKind = []reflect.Kind{
-		reflect.Array,
-		reflect.Chan,
-		reflect.Func,
-		reflect.Interface,
-		reflect.Map,
-		reflect.Ptr,
-		reflect.Slice,
-		reflect.Struct,
-		reflect.UnsafePointer,
+// Option configures a set created by Set()
+type Option func(*setStruct)
+
+// WithKey lets the set accept elements of any data kind, including the unsupported ones
+// e.g. structs having slice fields, pointers or maps
+// key must return a comparable value which identifies the element,
+// Add, Remove, Has and all set operations treat elements having the same key as equal
+func WithKey(key func(elem interface{}) interface{}) Option {
+	return func(s *setStruct) {
+		s.key = key
 	}
-)
+}
 
 // setMethods stores interface declaration of all setStruct methods
 type setMethods interface {
@@ -55,29 +61,50 @@ type setMethods interface {
 	// suppose, data type of the caller set is int
 	// now caller set calls this function then
 	// it will remove all elements from the set and
-	// any data except invalidKind types can be inserted for this set
+	// any data except Codec.UnsupportedKinds types can be inserted for this set
 	Clear()
 
 	// Copy copies the existing set to a new set and returns the new set
+	// the new set doesn't share any data with the existing set,
+	// so adding to or removing from one of them doesn't change the other
 	Copy() *setStruct
 
+	// ToPersistent returns an immutable persistent set having the same elements, data type and key function
+	// see Persistent
+	ToPersistent() *persistentSet
+
 	// Len returns the length of the existing set
 	Len() int
 
 	// Union performs the set union operation among the existing set and sets passed as params,
 	// stores data in a new set and returns the new set
+	// neither the existing set nor the parametric sets are modified
 	Union(sets ...*setStruct) (*setStruct, error)
 
 	// Intersection performs the set intersection operation among the existing set and sets passed as params,
 	// stores data in a new set and returns the new set
+	// neither the existing set nor the parametric sets are modified
 	Intersection(sets ...*setStruct) (*setStruct, error)
 
 	// Difference performs the set difference operation from the existing set and sets passed as params,
 	// stores data in a new set and returns the new set
 	// the set difference is found as follows
 	// the set calling this method - parametric set1 - parametric set2 - parametric set3 -...
+	// neither the existing set nor the parametric sets are modified
 	Difference(sets ...*setStruct) (*setStruct, error)
 
+	// UnionInPlace works like Union but stores the result in the existing set instead of a new set
+	// returns error if data types mismatched and also doesn't modify the existing set
+	UnionInPlace(sets ...*setStruct) error
+
+	// IntersectionInPlace works like Intersection but stores the result in the existing set instead of a new set
+	// returns error if data types mismatched and also doesn't modify the existing set
+	IntersectionInPlace(sets ...*setStruct) error
+
+	// DifferenceInPlace works like Difference but stores the result in the existing set instead of a new set
+	// returns error if data types mismatched and also doesn't modify the existing set
+	DifferenceInPlace(sets ...*setStruct) error
+
 	// MakeDisjoint makes the caller set and parametric set disjoint to each other.
 	// suppose, the call is like x.MakeDisjoint(y)
 	// then this function makes the sets x and y disjoint to each other
@@ -88,6 +115,7 @@ type setMethods interface {
 	// then the function creates a sub set of x having randomized elements equal to y and returns the sub set
 	// y = 0 is valid value as it will return empty set
 	// y < -1 or y > number of elements present in x is invalid choice
+	// the caller set is not modified
 	MakeSubSet(elemNum int) (*setStruct, error)
 
 	// Has checks whether the existing set has a specific element or not
@@ -97,7 +125,7 @@ type setMethods interface {
 	// suppose, the call is like x.IsDisjoint(y)
 	// then this function determines whether x and y are disjoint to each other or not
 	// and returns boolean value (true, false) and error (if any)
-	IsDisjoint(sets *setStruct) (bool, error)
+	IsDisjoint(set *setStruct) (bool, error)
 
 	// IsSubSet checks whether the caller set is a sub set of the parametric set
 	// suppose, the call is like x.IsSubSet(y)
@@ -114,6 +142,33 @@ type setMethods interface {
 	// ToSlice converts set to golang slice and return the slice
 	ToSlice() []interface{}
 
+	// All returns an iterator over the elements of the set in no particular order
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so adding to or removing from the set inside the loop doesn't change what is yielded
+	All() iter.Seq[interface{}]
+
+	// MarshalJSON encodes the set as a JSON object having the data kind and the elements
+	// e.g. {"kind":"int","elements":[1,2,3]}, the elements are sorted in ascending order
+	// returns error if the data kind isn't supported by internal/Codec e.g. a struct
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON replaces the elements and the data kind of the set with the decoded ones
+	// every element is validated like Add, so a malformed document is rejected and the set is not changed
+	// an element is decoded as the builtin type of the data kind, so elements of a named type e.g. type ID int come back as int
+	UnmarshalJSON(data []byte) error
+
+	// MarshalBinary encodes the set in the compact binary format of the package internal/Codec
+	// the encoded data has a versioned header with the data kind followed by the elements in ascending order, so equal sets are encoded equally
+	MarshalBinary() ([]byte, error)
+
+	// UnmarshalBinary replaces the elements and the data kind of the set with the decoded ones
+	// every element is validated like Add, so malformed data is rejected and the set is not changed
+	UnmarshalBinary(data []byte) error
+
+	// GobEncode and GobDecode let encoding/gob use the binary format
+	GobEncode() ([]byte, error)
+	GobDecode(data []byte) error
+
 	// Display converts set to a golang slice and
 	// prints the converted set (slice) on console screen
 	Display()
@@ -125,12 +180,21 @@ type setMethods interface {
 	// the set data kind is of type builtin reflect.Kind
 	// a set must contain elements having same data kind
 	checkDataKind(value interface{}) error
+
+	// keyOf returns the key of an element using the key function (if provided) else the element itself
+	keyOf(elem interface{}) interface{}
+
+	// checkSetsKind checks that the existing set and the parametric sets have the same data kind
+	// empty sets without data kind match any set
+	checkSetsKind(sets []*setStruct) error
 }
 
 // setStruct where set data are stored
+// set maps the key of every element to the element itself
 type setStruct struct {
-	set         map[interface{}]bool
+	set         map[interface{}]interface{}
 	setDataKind reflect.Kind
+	key         func(elem interface{}) interface{}
 }
 
 func (s *setStruct) Add(elem ...interface{}) error {
@@ -141,14 +205,14 @@ func (s *setStruct) Add(elem ...interface{}) error {
 	}
 
 	for _, e := range elem {
-		s.set[e] = true
+		s.set[s.keyOf(e)] = e
 	}
 	return nil
 }
 
 func (s *setStruct) Remove(elem ...interface{}) {
 	for _, e := range elem {
-		delete(s.set, e)
+		delete(s.set, s.keyOf(e))
 	}
 }
 
@@ -164,10 +228,15 @@ func (s *setStruct) Clear() {
 }
 
 func (s *setStruct) Copy() *setStruct {
-	return &setStruct{
-		set:         s.set,
+	copySet := &setStruct{
+		set:         make(map[interface{}]interface{}, len(s.set)),
 		setDataKind: s.setDataKind,
+		key:         s.key,
+	}
+	for key, elem := range s.set {
+		copySet.set[key] = elem
 	}
+	return copySet
 }
 
 func (s *setStruct) Len() int {
@@ -184,10 +253,10 @@ func (s *setStruct) Union(sets ...*setStruct) (*setStruct, error) {
 
 		if set.setDataKind != reflect.Invalid {
 			if unionSet.setDataKind != set.setDataKind {
-				return nil, errors.New("mismatched data types among sets")
+				return nil, &KindMismatchError{Expected: unionSet.setDataKind, Got: set.setDataKind}
 			}
-			for key := range set.set {
-				unionSet.set[key] = true
+			for key, elem := range set.set {
+				unionSet.set[key] = elem
 			}
 		}
 	}
@@ -197,6 +266,7 @@ func (s *setStruct) Union(sets ...*setStruct) (*setStruct, error) {
 
 func (s *setStruct) Intersection(sets ...*setStruct) (*setStruct, error) {
 	intersectionSet := Set()
+	intersectionSet.key = s.key
 	totalSetCount := len(sets) + 1 // +1 for s
 	elemFreqCount := make(map[interface{}]int)
 
@@ -214,7 +284,7 @@ func (s *setStruct) Intersection(sets ...*setStruct) (*setStruct, error) {
 
 		if set.setDataKind != reflect.Invalid {
 			if intersectionSet.setDataKind != set.setDataKind {
-				return nil, errors.New("mismatched data types among sets")
+				return nil, &KindMismatchError{Expected: intersectionSet.setDataKind, Got: set.setDataKind}
 			}
 			for key := range set.set {
 				elemFreqCount[key] += 1
@@ -222,9 +292,9 @@ func (s *setStruct) Intersection(sets ...*setStruct) (*setStruct, error) {
 		}
 	}
 
-	for elem, freq := range elemFreqCount {
+	for key, freq := range elemFreqCount {
 		if freq == totalSetCount {
-			intersectionSet.set[elem] = true
+			intersectionSet.set[key] = s.set[key]
 		}
 	}
 
@@ -239,27 +309,75 @@ func (s *setStruct) Difference(sets ...*setStruct) (*setStruct, error) {
 	}
 
 	if diffSet.setDataKind != reflect.Invalid && unionSet.setDataKind != reflect.Invalid && diffSet.setDataKind != unionSet.setDataKind {
-		return nil, errors.New("mismatched data types among sets")
+		return nil, &KindMismatchError{Expected: diffSet.setDataKind, Got: unionSet.setDataKind}
 	}
 
-	for elem := range unionSet.set {
-		if diffSet.set[elem] {
-			diffSet.Remove(elem)
-		}
+	for key := range unionSet.set {
+		delete(diffSet.set, key)
 	}
 
 	return diffSet, nil
 }
 
+func (s *setStruct) UnionInPlace(sets ...*setStruct) error {
+	if err := s.checkSetsKind(sets); err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+		if s.setDataKind == reflect.Invalid {
+			s.setDataKind = set.setDataKind
+		}
+		for key, elem := range set.set {
+			s.set[key] = elem
+		}
+	}
+	return nil
+}
+
+func (s *setStruct) IntersectionInPlace(sets ...*setStruct) error {
+	if err := s.checkSetsKind(sets); err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+		if s.setDataKind == reflect.Invalid {
+			s.setDataKind = set.setDataKind
+		}
+	}
+	for key := range s.set {
+		for _, set := range sets {
+			if _, has := set.set[key]; !has {
+				delete(s.set, key)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *setStruct) DifferenceInPlace(sets ...*setStruct) error {
+	if err := s.checkSetsKind(sets); err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+		for key := range set.set {
+			delete(s.set, key)
+		}
+	}
+	return nil
+}
+
 func (s *setStruct) MakeDisjoint(set *setStruct) error {
 	if s.setDataKind != reflect.Invalid && set.setDataKind != reflect.Invalid && s.setDataKind != set.setDataKind {
-		return errors.New("mismatched data types among sets")
+		return &KindMismatchError{Expected: s.setDataKind, Got: set.setDataKind}
 	}
 
-	for elem := range set.set {
-		if s.set[elem] {
-			s.Remove(elem)
-			set.Remove(elem)
+	for key := range set.set {
+		if _, has := s.set[key]; has {
+			delete(s.set, key)
+			delete(set.set, key)
 		}
 	}
 
@@ -272,21 +390,23 @@ func (s *setStruct) MakeSubSet(elemNum int) (*setStruct, error) {
 
 	subSet := Set()
 	if elemNum < 0 || elemNum > setSliceLen {
-		return subSet, errors.New("invalid element number provided to make sub set")
+		return subSet, ErrInvalidElementNumber
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(setSliceLen, func(i, j int) { setSlice[i], setSlice[j] = setSlice[j], setSlice[i] })
+	// a local source, so concurrent callers don't reseed the global one
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng.Shuffle(setSliceLen, func(i, j int) { setSlice[i], setSlice[j] = setSlice[j], setSlice[i] })
 
 	subSet.setDataKind = s.setDataKind
+	subSet.key = s.key
 	for _, elem := range setSlice[:elemNum] {
-		subSet.set[elem] = true
+		subSet.set[s.keyOf(elem)] = elem
 	}
 	return subSet, nil
 }
 
 func (s *setStruct) Has(elem interface{}) bool {
-	if _, has := s.set[elem]; !has {
+	if _, has := s.set[s.keyOf(elem)]; !has {
 		return false
 	}
 	return true
@@ -294,19 +414,19 @@ func (s *setStruct) Has(elem interface{}) bool {
 
 func (s *setStruct) IsDisjoint(set *setStruct) (bool, error) {
 	disjointSet, err := s.Intersection(set)
-	if disjointSet.Len() == 0 || err != nil {
+	if err != nil {
 		return false, err
 	}
-	return true, nil
+	return disjointSet.Len() == 0, nil
 }
 
 func (s *setStruct) IsSubSet(set *setStruct) (bool, error) {
 	if s.setDataKind != reflect.Invalid && set.setDataKind != reflect.Invalid && s.setDataKind != set.setDataKind {
-		return false, errors.New("mismatched data types among sets")
+		return false, &KindMismatchError{Expected: s.setDataKind, Got: set.setDataKind}
 	}
 
-	for elem := range s.set {
-		if !set.set[elem] {
+	for key := range s.set {
+		if _, has := set.set[key]; !has {
 			return false, nil
 		}
 	}
@@ -315,11 +435,11 @@ func (s *setStruct) IsSubSet(set *setStruct) (bool, error) {
 
 func (s *setStruct) IsSuperSet(set *setStruct) (bool, error) {
 	if s.setDataKind != reflect.Invalid && set.setDataKind != reflect.Invalid && s.setDataKind != set.setDataKind {
-		return false, errors.New("mismatched data types among sets")
+		return false, &KindMismatchError{Expected: s.setDataKind, Got: set.setDataKind}
 	}
 
-	for elem := range set.set {
-		if !s.set[elem] {
+	for key := range set.set {
+		if _, has := s.set[key]; !has {
 			return false, nil
 		}
 	}
@@ -328,7 +448,7 @@ func (s *setStruct) IsSuperSet(set *setStruct) (bool, error) {
 
 func (s *setStruct) ToSlice() []interface{} {
 	setSlice := make([]interface{}, 0)
-	for elem := range s.set {
+	for _, elem := range s.set {
 		setSlice = append(setSlice, elem)
 	}
 	return setSlice
@@ -343,15 +463,33 @@ func (s *setStruct) checkDataKind(val interface{}) error {
 	valKind := reflect.TypeOf(val).Kind()
 
 	if s.setDataKind != reflect.Invalid && s.setDataKind != valKind {
-		return errors.New("invalid value type")
+		return &KindMismatchError{Expected: s.setDataKind, Got: valKind}
 	}
 
-	for _, kind := range invalidKind {
-		if valKind == kind {
-			return fmt.Errorf("%v is not supported type for set", valKind)
-		}
+	if s.key == nil && !Codec.IsSupportedType(reflect.TypeOf(val)) {
+		return &UnsupportedKindError{Kind: valKind}
 	}
 
 	s.setDataKind = valKind
 	return nil
 }
+
+func (s *setStruct) checkSetsKind(sets []*setStruct) error {
+	setsDataKind := s.setDataKind
+	for _, set := range sets {
+		if setsDataKind == reflect.Invalid {
+			setsDataKind = set.setDataKind
+		}
+		if set.setDataKind != reflect.Invalid && set.setDataKind != setsDataKind {
+			return &KindMismatchError{Expected: setsDataKind, Got: set.setDataKind}
+		}
+	}
+	return nil
+}
+
+func (s *setStruct) keyOf(elem interface{}) interface{} {
+	if s.key != nil {
+		return s.key(elem)
+	}
+	return elem
+}
@@ -0,0 +1,240 @@
+package Set
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+)
+
+// sorted returns the elements of the set in ascending order
+func sorted(s *setStruct) []interface{} {
+	elems := s.ToSlice()
+	Codec.Sort(elems)
+	return elems
+}
+
+// intSet returns a set holding the given ints
+func intSet(elems ...interface{}) *setStruct {
+	s := Set()
+	_ = s.Add(elems...)
+	return s
+}
+
+func TestSetIsDisjoint(t *testing.T) {
+	x, y, z := Set(), Set(), Set()
+	_ = x.Add(1, 2)
+	_ = y.Add(3)
+	_ = z.Add(2)
+
+	if disjoint, err := x.IsDisjoint(y); err != nil || !disjoint {
+		t.Fatalf("{1, 2}.IsDisjoint({3}) = %v, %v, want true, nil", disjoint, err)
+	}
+	if disjoint, err := x.IsDisjoint(z); err != nil || disjoint {
+		t.Fatalf("{1, 2}.IsDisjoint({2}) = %v, %v, want false, nil", disjoint, err)
+	}
+	if disjoint, err := x.IsDisjoint(Set()); err != nil || !disjoint {
+		t.Fatalf("{1, 2}.IsDisjoint({}) = %v, %v, want true, nil", disjoint, err)
+	}
+}
+
+func TestSetIsDisjointKindMismatch(t *testing.T) {
+	x, y := Set(), Set()
+	_ = x.Add(1)
+	_ = y.Add("a")
+
+	var mismatch *KindMismatchError
+	if disjoint, err := x.IsDisjoint(y); !errors.As(err, &mismatch) || disjoint {
+		t.Fatalf("IsDisjoint() = %v, %v, want false, *KindMismatchError", disjoint, err)
+	}
+}
+
+func TestConcurrentSetIsDisjoint(t *testing.T) {
+	x, y, z, w := NewConcurrent(), NewConcurrent(), NewConcurrent(), NewConcurrent()
+	_ = x.Add(1, 2)
+	_ = y.Add(3)
+	_ = z.Add(2)
+	_ = w.Add("a")
+
+	if disjoint, err := x.IsDisjoint(y); err != nil || !disjoint {
+		t.Fatalf("{1, 2}.IsDisjoint({3}) = %v, %v, want true, nil", disjoint, err)
+	}
+	if disjoint, err := x.IsDisjoint(z); err != nil || disjoint {
+		t.Fatalf("{1, 2}.IsDisjoint({2}) = %v, %v, want false, nil", disjoint, err)
+	}
+	var mismatch *KindMismatchError
+	if _, err := x.IsDisjoint(w); !errors.As(err, &mismatch) {
+		t.Fatalf("IsDisjoint() = %v, want *KindMismatchError", err)
+	}
+}
+
+func TestSetElementsIdentifiedByKey(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	var unsupported *UnsupportedKindError
+	if err := Set().Add(record{ID: 1}); !errors.As(err, &unsupported) {
+		t.Fatalf("Add() of a struct having a slice field without WithKey = %v, want *UnsupportedKindError", err)
+	}
+
+	s := Set(WithKey(func(elem interface{}) interface{} { return elem.(record).ID }))
+	if err := s.Add(record{1, []string{"a"}}, record{2, nil}, record{1, []string{"b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Has(record{ID: 2}) {
+		t.Fatalf("set %v, want the records 1 and 2", s.ToSlice())
+	}
+	s.Remove(record{ID: 1})
+	if s.Has(record{ID: 1}) || s.Len() != 1 {
+		t.Fatalf("Remove() left %v", s.ToSlice())
+	}
+}
+
+func TestSetPointerElements(t *testing.T) {
+	x, y := 1, 1
+	var unsupported *UnsupportedKindError
+	if err := Set().Add(&x); !errors.As(err, &unsupported) {
+		t.Fatalf("Add() of a pointer without WithKey = %v, want *UnsupportedKindError", err)
+	}
+
+	s := Set(WithKey(func(elem interface{}) interface{} { return *elem.(*int) }))
+	if err := s.Add(&x, &y); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 1 || !s.Has(&y) {
+		t.Fatalf("set of two pointers to equal values has %d elements, want 1", s.Len())
+	}
+}
+
+func TestSetComparableElements(t *testing.T) {
+	s := Set()
+	if err := s.Add([2]int{1, 2}, [2]int{1, 2}, [2]int{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Has([2]int{3, 4}) {
+		t.Fatalf("array set %v, want [1 2] and [3 4]", s.ToSlice())
+	}
+
+	// hashing an interface holding a slice panics, so such structs need WithKey
+	type boxed struct{ V interface{} }
+	var unsupported *UnsupportedKindError
+	if err := Set().Add(boxed{[]int{1}}); !errors.As(err, &unsupported) {
+		t.Fatalf("Add() of a struct having an interface field = %v, want *UnsupportedKindError", err)
+	}
+}
+
+func TestSetCopyIsDeep(t *testing.T) {
+	s := intSet(1, 2)
+	c := s.Copy()
+	_ = c.Add(3)
+	c.Remove(1)
+	if got := sorted(s); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatalf("changing the copy changed the set to %v", got)
+	}
+	_ = s.Add(4)
+	if got := sorted(c); !reflect.DeepEqual(got, []interface{}{2, 3}) {
+		t.Fatalf("changing the set changed the copy to %v", got)
+	}
+}
+
+func TestSetOperationsLeaveInputsUnchanged(t *testing.T) {
+	x, y, z := intSet(1, 2, 3), intSet(3, 4), intSet(1, 5)
+	check := func(op string) {
+		t.Helper()
+		if got := sorted(x); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+			t.Fatalf("%s changed the receiver to %v", op, got)
+		}
+		if got := sorted(y); !reflect.DeepEqual(got, []interface{}{3, 4}) {
+			t.Fatalf("%s changed the first argument to %v", op, got)
+		}
+		if got := sorted(z); !reflect.DeepEqual(got, []interface{}{1, 5}) {
+			t.Fatalf("%s changed the second argument to %v", op, got)
+		}
+	}
+
+	union, err := x.Union(y, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Union")
+	_ = union.Add(6)
+	check("adding to the union")
+
+	diff, err := x.Difference(y, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Difference")
+	if got := sorted(diff); !reflect.DeepEqual(got, []interface{}{2}) {
+		t.Fatalf("Difference() = %v, want [2]", got)
+	}
+	_ = diff.Add(7)
+	check("adding to the difference")
+
+	if _, err := x.Intersection(y, z); err != nil {
+		t.Fatal(err)
+	}
+	check("Intersection")
+
+	sub, err := x.MakeSubSet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("MakeSubSet")
+	if sub.Len() != 2 {
+		t.Fatalf("MakeSubSet(2) has %d elements", sub.Len())
+	}
+	for _, elem := range sub.ToSlice() {
+		if !x.Has(elem) {
+			t.Fatalf("MakeSubSet(2) = %v has an element which isn't in the set", sub.ToSlice())
+		}
+	}
+	_ = sub.Add(8)
+	check("adding to the sub set")
+}
+
+func TestSetInPlaceMatchesAllocating(t *testing.T) {
+	tests := []struct {
+		name     string
+		inPlace  func(s *setStruct, sets ...*setStruct) error
+		allocing func(s *setStruct, sets ...*setStruct) (*setStruct, error)
+	}{
+		{"Union", (*setStruct).UnionInPlace, (*setStruct).Union},
+		{"Intersection", (*setStruct).IntersectionInPlace, (*setStruct).Intersection},
+		{"Difference", (*setStruct).DifferenceInPlace, (*setStruct).Difference},
+	}
+	inputs := [][]*setStruct{
+		{intSet(1, 2, 3), intSet(2, 3, 4), intSet(3, 5)},
+		{intSet(1, 2), intSet()},
+		{Set(), intSet(1, 2)},
+		{intSet(1, 2)},
+	}
+
+	for _, tt := range tests {
+		for _, in := range inputs {
+			want, err := tt.allocing(in[0], in[1:]...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := in[0].Copy()
+			if err := tt.inPlace(s, in[1:]...); err != nil {
+				t.Fatal(err)
+			}
+			if got := sorted(s); !reflect.DeepEqual(got, sorted(want)) || s.setDataKind != want.setDataKind {
+				t.Fatalf("%sInPlace gives %v of kind %v, %s gives %v of kind %v",
+					tt.name, got, s.setDataKind, tt.name, sorted(want), want.setDataKind)
+			}
+		}
+
+		s := intSet(1, 2)
+		var mismatch *KindMismatchError
+		if err := tt.inPlace(s, intSet("a")); !errors.As(err, &mismatch) {
+			t.Fatalf("%sInPlace with a string set = %v, want *KindMismatchError", tt.name, err)
+		}
+		if got := sorted(s); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+			t.Fatalf("a failed %sInPlace changed the set to %v", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package Stack
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Codec"
+
+func (st *stackStruct) MarshalBinary() ([]byte, error) {
+	return Codec.MarshalBinary(st.stackDataKind, st.stack)
+}
+
+func (st *stackStruct) UnmarshalBinary(data []byte) error {
+	kind, elems, err := Codec.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if err := st.checkCapacity(len(elems)); err != nil {
+		return err
+	}
+
+	decoded := Stack()
+	decoded.equal = st.equal
+	decoded.stackDataKind = kind
+	if err := decoded.Push(elems...); err != nil {
+		return err
+	}
+
+	st.stack = decoded.stack
+	st.stackDataKind = decoded.stackDataKind
+	return nil
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (st *stackStruct) GobEncode() ([]byte, error) {
+	return st.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (st *stackStruct) GobDecode(data []byte) error {
+	return st.UnmarshalBinary(data)
+}
+
+func (cs *concurrentStack) MarshalBinary() ([]byte, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.MarshalBinary()
+}
+
+func (cs *concurrentStack) UnmarshalBinary(data []byte) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.UnmarshalBinary(data)
+}
+
+// GobEncode uses the same format as MarshalBinary
+func (cs *concurrentStack) GobEncode() ([]byte, error) {
+	return cs.MarshalBinary()
+}
+
+// GobDecode uses the same format as UnmarshalBinary
+func (cs *concurrentStack) GobDecode(data []byte) error {
+	return cs.UnmarshalBinary(data)
+}
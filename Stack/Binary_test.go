@@ -0,0 +1,11 @@
+package Stack
+
+import (
+	"testing"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/CodecTest"
+)
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	CodecTest.FuzzUnmarshalBinary(f, func() *stackStruct { return Stack() }, (*stackStruct).Push)
+}
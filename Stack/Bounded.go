@@ -0,0 +1,106 @@
+package Stack
+
+import (
+	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Bounded"
+)
+
+// OverflowPolicy decides what Push does when the stack is at its capacity (see WithCapacity)
+type OverflowPolicy int
+
+const (
+	// Reject returns an *OverflowError (matching ErrFull) and doesn't push any element
+	Reject = OverflowPolicy(Bounded.Reject)
+
+	// DropBottom removes as many bottom-most i.e. earliest inserted elements as needed to push the new ones
+	DropBottom = OverflowPolicy(Bounded.DropOldest)
+
+	// DropIncoming discards the pushed elements without an error
+	DropIncoming = OverflowPolicy(Bounded.DropIncoming)
+
+	// Block makes the Push of the stack created by NewConcurrent wait until there is room for every pushed element
+	// a stack created by Stack() has no other goroutine to make room, so it behaves like Reject
+	Block = OverflowPolicy(Bounded.Block)
+)
+
+// OverflowStats counts the elements handled by the overflow policy since the stack was created
+type OverflowStats struct {
+	// Rejected is the number of elements of the rejected pushes
+	Rejected int
+
+	// DroppedBottom is the number of bottom-most elements removed by DropBottom
+	DroppedBottom int
+
+	// DroppedIncoming is the number of pushed elements discarded by DropIncoming
+	DroppedIncoming int
+}
+
+// WithCapacity limits the number of elements of the stack to capacity, capacity <= 0 means unbounded
+// policy decides what Push does when the pushed elements don't fit, a Push is still all-or-nothing:
+// the elements are either pushed together or not at all, and pushing more elements than capacity
+// at once is always rejected as they can never fit
+func WithCapacity(capacity int, policy OverflowPolicy) Option {
+	return func(st *stackStruct) {
+		st.limit.Capacity = capacity
+		st.limit.Policy = Bounded.Policy(policy)
+	}
+}
+
+func (st *stackStruct) Capacity() int {
+	return st.limit.Capacity
+}
+
+func (st *stackStruct) Overflows() OverflowStats {
+	return OverflowStats{
+		Rejected:        st.limit.Stats.Rejected,
+		DroppedBottom:   st.limit.Stats.DroppedOldest,
+		DroppedIncoming: st.limit.Stats.DroppedIncoming,
+	}
+}
+
+func (st *stackStruct) makeRoom(count int) (bool, error) {
+	stackSize := st.Size()
+	dropCount, push, rejected := st.limit.MakeRoom(stackSize, count)
+	if rejected {
+		return false, &OverflowError{Capacity: st.limit.Capacity, Size: stackSize, Count: count}
+	}
+
+	// DropBottom, the bottom-most elements are at the start of the slice
+	if dropCount > 0 {
+		remaining := copy(st.stack, st.stack[dropCount:])
+		clear(st.stack[remaining:])
+		st.stack = st.stack[:remaining]
+	}
+	return push, nil
+}
+
+func (st *stackStruct) checkCapacity(count int) error {
+	if !st.limit.Fits(count) {
+		return &OverflowError{Capacity: st.limit.Capacity, Size: 0, Count: count}
+	}
+	return nil
+}
+
+func (st *stackStruct) mustWait(elem []interface{}) bool {
+	if !st.limit.MustWait(st.Size(), len(elem)) {
+		return false
+	}
+	// a push which fails anyway returns its error right away instead of waiting
+	for _, e := range elem {
+		if reflect.TypeOf(e).Kind() != st.stackDataKind {
+			return false
+		}
+	}
+	return true
+}
+
+func (cs *concurrentStack) Capacity() int {
+	return cs.st.Capacity()
+}
+
+func (cs *concurrentStack) Overflows() OverflowStats {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Overflows()
+}
@@ -0,0 +1,71 @@
+package Stack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStackDropBottom(t *testing.T) {
+	st := Stack(WithCapacity(3, DropBottom))
+	_ = st.Push(1, 2, 3)
+	if err := st.Push(4, 5); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{3, 4, 5}) {
+		t.Fatalf("stack %v, want [3 4 5]", got)
+	}
+	if got := st.Overflows(); got != (OverflowStats{DroppedBottom: 2}) {
+		t.Fatalf("Overflows() = %+v, want 2 dropped from the bottom", got)
+	}
+}
+
+func TestStackDropIncoming(t *testing.T) {
+	st := Stack(WithCapacity(3, DropIncoming))
+	_ = st.Push(1, 2)
+	if err := st.Push(3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatalf("stack %v, want [1 2]", got)
+	}
+	if err := st.Push(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.Overflows(); got != (OverflowStats{DroppedIncoming: 2}) {
+		t.Fatalf("Overflows() = %+v, want 2 incoming dropped", got)
+	}
+}
+
+func TestStackReject(t *testing.T) {
+	for _, policy := range []OverflowPolicy{Reject, Block} {
+		st := Stack(WithCapacity(3, policy))
+		_ = st.Push(1, 2)
+		var overflow *OverflowError
+		if err := st.Push(3, 4); !errors.As(err, &overflow) || !errors.Is(err, ErrFull) {
+			t.Fatalf("policy %d: Push() = %v, want an *OverflowError matching ErrFull", policy, err)
+		}
+		if overflow.Capacity != 3 || overflow.Size != 2 || overflow.Count != 2 {
+			t.Fatalf("policy %d: %+v, want capacity 3, size 2 and count 2", policy, overflow)
+		}
+		if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) {
+			t.Fatalf("policy %d: a rejected Push changed the stack to %v", policy, got)
+		}
+		if got := st.Overflows(); got != (OverflowStats{Rejected: 2}) {
+			t.Fatalf("policy %d: Overflows() = %+v, want 2 rejected", policy, got)
+		}
+	}
+}
+
+func TestStackPushBiggerThanCapacity(t *testing.T) {
+	st := Stack(WithCapacity(2, DropBottom))
+	if err := st.Push(1, 2, 3); !errors.Is(err, ErrFull) {
+		t.Fatalf("Push() of more elements than capacity = %v, want ErrFull", err)
+	}
+	if st.Size() != 0 || st.Capacity() != 2 {
+		t.Fatalf("size %d and capacity %d, want 0 and 2", st.Size(), st.Capacity())
+	}
+	if err := st.UnmarshalJSON([]byte(`{"kind":"int","elements":[1,2,3]}`)); !errors.Is(err, ErrFull) {
+		t.Fatalf("UnmarshalJSON() of more elements than capacity = %v, want ErrFull", err)
+	}
+}
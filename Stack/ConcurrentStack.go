@@ -0,0 +1,118 @@
+package Stack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NewConcurrent a global function which creates, initializes and returns a stack instance
+// which is safe to use from multiple goroutines
+// it accepts the same Option values as Stack()
+func NewConcurrent(opts ...Option) *concurrentStack {
+	cs := &concurrentStack{
+		st: Stack(opts...),
+	}
+	cs.room = sync.NewCond(&cs.mu)
+	return cs
+}
+
+// concurrentStack guards every stackStruct method with a read write mutex
+// compound methods like TopAndPop and TopsAndPops are performed under a single lock,
+// so no other goroutine can modify the stack between the top and the pop
+// the methods removing elements broadcast room to wake up the pushes waiting under the Block policy
+type concurrentStack struct {
+	mu   sync.RWMutex
+	room *sync.Cond
+	st   *stackStruct
+}
+
+func (cs *concurrentStack) Push(elem ...interface{}) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for cs.st.mustWait(elem) {
+		cs.room.Wait()
+	}
+	return cs.st.Push(elem...)
+}
+
+func (cs *concurrentStack) Pop() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.Pop()
+}
+
+func (cs *concurrentStack) Pops(popCount int) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.Pops(popCount)
+}
+
+func (cs *concurrentStack) RemoveAll() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	cs.st.RemoveAll()
+}
+
+func (cs *concurrentStack) Clear() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	cs.st.Clear()
+}
+
+func (cs *concurrentStack) Top() (interface{}, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Top()
+}
+
+func (cs *concurrentStack) Tops(topCount int) ([]interface{}, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Tops(topCount)
+}
+
+func (cs *concurrentStack) TopAndPop() (interface{}, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.TopAndPop()
+}
+
+func (cs *concurrentStack) TopsAndPops(count int) ([]interface{}, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.TopsAndPops(count)
+}
+
+func (cs *concurrentStack) Size() int {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Size()
+}
+
+func (cs *concurrentStack) Empty() bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Empty()
+}
+
+func (cs *concurrentStack) Search(elem interface{}) int {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.Search(elem)
+}
+
+func (cs *concurrentStack) Display() {
+	fmt.Println(cs.ToSlice())
+}
+
+func (cs *concurrentStack) ToSlice() []interface{} {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.ToSlice()
+}
@@ -0,0 +1,134 @@
+package Stack
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentStackParallelPushPop(t *testing.T) {
+	const goroutines, perGoroutine = 8, 500
+	cs := NewConcurrent()
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				if err := cs.Push(g*perGoroutine + i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if got := cs.Size(); got != goroutines*perGoroutine {
+		t.Fatalf("Size() = %d, want %d", got, goroutines*perGoroutine)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	record := func(elems ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, e := range elems {
+			seen[e.(int)]++
+		}
+	}
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for {
+				var err error
+				switch g % 3 {
+				case 0:
+					var elem interface{}
+					if elem, err = cs.TopAndPop(); err == nil {
+						record(elem)
+					}
+				case 1:
+					var elems []interface{}
+					if elems, err = cs.TopsAndPops(3); err == nil {
+						record(elems...)
+					} else if errors.Is(err, ErrCountExceedsSize) {
+						// fewer than 3 left, fall back to single pops
+						var elem interface{}
+						if elem, err = cs.TopAndPop(); err == nil {
+							record(elem)
+						}
+					}
+				default:
+					_, _ = cs.Top()
+					_ = cs.Search(0)
+					var elem interface{}
+					if elem, err = cs.TopAndPop(); err == nil {
+						record(elem)
+					}
+				}
+				if errors.Is(err, ErrEmpty) {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*perGoroutine {
+		t.Fatalf("popped %d distinct elements, want %d", len(seen), goroutines*perGoroutine)
+	}
+	for elem, count := range seen {
+		if count != 1 {
+			t.Fatalf("element %d popped %d times", elem, count)
+		}
+	}
+}
+
+func TestConcurrentStackPushPopInterleaved(t *testing.T) {
+	cs := NewConcurrent()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				_ = cs.Push(i, i+1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				_ = cs.Pop()
+				_, _ = cs.TopsAndPops(2)
+				_ = cs.ToSlice()
+			}
+		}()
+	}
+	wg.Wait()
+	if cs.Size() < 0 || cs.Size() != len(cs.ToSlice()) {
+		t.Fatalf("Size() = %d, ToSlice() has %d elements", cs.Size(), len(cs.ToSlice()))
+	}
+}
+
+func TestConcurrentStackBlockPolicy(t *testing.T) {
+	cs := NewConcurrent(WithCapacity(2, Block))
+	_ = cs.Push(1, 2)
+
+	pushed := make(chan error)
+	go func() { pushed <- cs.Push(3) }()
+	if _, err := cs.TopAndPop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-pushed; err != nil {
+		t.Fatal(err)
+	}
+	if got := cs.ToSlice(); len(got) != 2 || got[1] != 3 {
+		t.Fatalf("ToSlice() = %v, want [1 3]", got)
+	}
+}
@@ -0,0 +1,111 @@
+package Stack
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrEmpty is returned when an element is requested from or popped from an empty stack
+	ErrEmpty = errors.New("invalid operation as stack is empty")
+
+	// ErrCountExceedsSize is matched (with errors.Is) by the errors returned when
+	// the requested element count is bigger than the size of the stack
+	ErrCountExceedsSize = errors.New("invalid operation as count is greater than the stack size")
+
+	// ErrNegativeCount is matched (with errors.Is) by the errors returned when the requested element count is negative
+	ErrNegativeCount = errors.New("invalid operation as count is negative")
+
+	// ErrFull is matched (with errors.Is) by the errors returned when the pushed elements
+	// don't fit in a stack created WithCapacity
+	ErrFull = errors.New("invalid operation as stack is full")
+)
+
+// KindMismatchError is returned when an element's data kind differs from the data kind of the stack
+type KindMismatchError struct {
+	Expected reflect.Kind
+	Got      reflect.Kind
+}
+
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("invalid value type: expected %v, got %v", e.Expected, e.Got)
+}
+
+// UnsupportedKindError is returned when an element's data kind can't be stored in a stack
+// see Codec.UnsupportedKinds and the options which lift this restriction
+type UnsupportedKindError struct {
+	Kind reflect.Kind
+}
+
+func (e *UnsupportedKindError) Error() string {
+	return fmt.Sprintf("%v is not supported type for stack", e.Kind)
+}
+
+// ViewIndexError is the panic value of View.At when Index is out of the range [0, Len)
+type ViewIndexError struct {
+	Index int
+	Len   int
+}
+
+func (e *ViewIndexError) Error() string {
+	return fmt.Sprintf("Stack: view index %d out of range [0, %d)", e.Index, e.Len)
+}
+
+// OverflowError is returned when Count pushed elements don't fit in a stack having Size elements and the given Capacity
+// it matches ErrFull
+type OverflowError struct {
+	Capacity int
+	Size     int
+	Count    int
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("invalid operation as %d more elements exceed the stack capacity(%d) with size(%d)", e.Count, e.Capacity, e.Size)
+}
+
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrFull
+}
+
+// countExceedsSizeError describes which count (pop, top, front, ...) is bigger than the stack size
+// it matches ErrCountExceedsSize
+type countExceedsSizeError struct {
+	op    string
+	count int
+	size  int
+}
+
+func (e *countExceedsSizeError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is greater than the stack size(%d)", e.op, e.count, e.size)
+}
+
+func (e *countExceedsSizeError) Is(target error) bool {
+	return target == ErrCountExceedsSize
+}
+
+// negativeCountError describes which count (pop, top, ...) is negative
+// it matches ErrNegativeCount
+type negativeCountError struct {
+	op    string
+	count int
+}
+
+func (e *negativeCountError) Error() string {
+	return fmt.Sprintf("invalid operation as %s count (%d) is negative", e.op, e.count)
+}
+
+func (e *negativeCountError) Is(target error) bool {
+	return target == ErrNegativeCount
+}
+
+// checkCount returns error if the count of the op is negative or bigger than the size of the stack
+func checkCount(op string, count, size int) error {
+	if count < 0 {
+		return &negativeCountError{op: op, count: count}
+	}
+	if count > size {
+		return &countExceedsSizeError{op: op, count: count, size: size}
+	}
+	return nil
+}
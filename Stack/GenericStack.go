@@ -0,0 +1,201 @@
+package Stack
+
+import (
+	"fmt"
+	"iter"
+)
+
+// New a global function which creates, initializes and returns a type safe stack instance
+// elements are compared with == (used by Search)
+func New[T comparable]() *genericStack[T] {
+	return NewWithEqual[T](func(a, b T) bool { return a == b })
+}
+
+// NewWithEqual creates, initializes and returns a type safe stack instance
+// for element types which are not comparable with ==
+// equal is used by Search to compare two elements
+func NewWithEqual[T any](equal func(a, b T) bool) *genericStack[T] {
+	return &genericStack[T]{
+		stack: make([]T, 0),
+		equal: equal,
+	}
+}
+
+// genericStack where type safe stack data are stored
+type genericStack[T any] struct {
+	stack []T
+	equal func(a, b T) bool
+}
+
+// genericStackMethods stores interface declaration of all genericStack methods
+type genericStackMethods[T any] interface {
+	// Push adds one or more elements to an existing stack
+	Push(elem ...T)
+
+	// Pop removes the top element i.e. last inserted element from the stack
+	Pop() error
+
+	// Pops can pop multiple elements from the top of the stack
+	// returns error if the popCount number is negative or bigger than the size of the stack
+	Pops(popCount int) error
+
+	// RemoveAll removes all elements from the caller stack
+	RemoveAll()
+
+	// Top returns the top element i.e. last inserted element from the stack
+	// and error (if stack is empty)
+	Top() (T, error)
+
+	// Tops returns top elements i.e. the latest elements equal to topCount (stored in a new slice)
+	// and error (if topCount is negative or bigger than the size of the stack)
+	Tops(topCount int) ([]T, error)
+
+	// TopAndPop retrieves the Top() element from the stack
+	// returns the top element and also Pop() from the stack
+	// also returns error (if any)
+	TopAndPop() (T, error)
+
+	// TopsAndPops returns top elements i.e. the latest elements equal to count (stored in a slice)
+	// and also pop those elements from the stack
+	// and error (if any)
+	TopsAndPops(count int) ([]T, error)
+
+	// Size returns the size of an existing stack
+	Size() int
+
+	// Empty checks whether the stack is empty or not
+	// returns true if empty else false
+	Empty() bool
+
+	// Search finds the parametric element in the stack
+	// if the element is found then returns the position from the Top() else -1 (not found)
+	// N.B. Top() is taken as position 1
+	Search(elem T) int
+
+	// Display prints the stack value as slice on console screen
+	// the left most data is the first inserted value
+	Display()
+
+	// ToSlice returns a copy of the stack as slice
+	// changing the returned slice doesn't change the stack, use View to read the stack without copying
+	ToSlice() []T
+
+	// View returns a read-only view of the stack which doesn't copy the elements
+	View() View[T]
+
+	// All returns an iterator over the stack from the Top() to the bottom
+	// it yields the position of every element (Top() is taken as position 1, like Search) and the element
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so pushing to or popping from the stack inside the loop doesn't change what is yielded
+	All() iter.Seq2[int, T]
+
+	// Backward returns an iterator over the stack from the bottom to the Top()
+	// it yields the same position and element pairs as All but in reverse order
+	// and walks a snapshot in the same way
+	Backward() iter.Seq2[int, T]
+
+	// Drain returns an iterator which pops and yields the Top() element until the stack is empty
+	// or the loop stops, elements pushed inside the loop are drained too
+	Drain() iter.Seq[T]
+}
+
+func (st *genericStack[T]) Push(elem ...T) {
+	st.stack = append(st.stack, elem...)
+}
+
+func (st *genericStack[T]) Pop() error {
+	stackSize := st.Size()
+	if stackSize == 0 {
+		return ErrEmpty
+	}
+
+	var zero T
+	st.stack[stackSize-1] = zero
+	st.stack = st.stack[:stackSize-1]
+	return nil
+}
+
+func (st *genericStack[T]) Pops(popCount int) error {
+	stackSize := st.Size()
+	if err := checkCount("pop", popCount, stackSize); err != nil {
+		return err
+	}
+
+	var zero T
+	for i := stackSize - popCount; i < stackSize; i++ {
+		st.stack[i] = zero
+	}
+	st.stack = st.stack[:stackSize-popCount]
+	return nil
+}
+
+func (st *genericStack[T]) RemoveAll() {
+	st.stack = make([]T, 0)
+}
+
+func (st *genericStack[T]) Top() (T, error) {
+	stackSize := st.Size()
+	if stackSize == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return st.stack[stackSize-1], nil
+}
+
+func (st *genericStack[T]) Tops(topCount int) ([]T, error) {
+	stackSize := st.Size()
+	if err := checkCount("top", topCount, stackSize); err != nil {
+		return nil, err
+	}
+
+	return append([]T(nil), st.stack[stackSize-topCount:stackSize]...), nil
+}
+
+func (st *genericStack[T]) TopAndPop() (T, error) {
+	elem, err := st.Top()
+	if err != nil {
+		return elem, err
+	}
+	if err := st.Pop(); err != nil {
+		return elem, err
+	}
+	return elem, nil
+}
+
+func (st *genericStack[T]) TopsAndPops(count int) ([]T, error) {
+	elemSlice, err := st.Tops(count)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.Pops(count); err != nil {
+		return nil, err
+	}
+	return elemSlice, nil
+}
+
+func (st *genericStack[T]) Size() int {
+	return len(st.stack)
+}
+
+func (st *genericStack[T]) Empty() bool {
+	return st.Size() == 0
+}
+
+func (st *genericStack[T]) Search(elem T) int {
+	stackSize := st.Size()
+	for i := stackSize - 1; i >= 0; i-- {
+		if st.equal(st.stack[i], elem) {
+			return stackSize - i
+		}
+	}
+	return -1
+}
+
+func (st *genericStack[T]) Display() {
+	fmt.Println(st.stack)
+}
+
+func (st *genericStack[T]) ToSlice() []T {
+	return append([]T(nil), st.stack...)
+}
@@ -0,0 +1,100 @@
+package Stack
+
+import "iter"
+
+func (st *stackStruct) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromTop(st.ToSlice())(yield)
+	}
+}
+
+func (st *stackStruct) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromBottom(st.ToSlice())(yield)
+	}
+}
+
+func (st *stackStruct) Drain() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for !st.Empty() {
+			elem, _ := st.TopAndPop()
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+func (st *genericStack[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		allFromTop(st.ToSlice())(yield)
+	}
+}
+
+func (st *genericStack[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		allFromBottom(st.ToSlice())(yield)
+	}
+}
+
+func (st *genericStack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !st.Empty() {
+			elem, _ := st.TopAndPop()
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over a snapshot taken under the read lock, see stackStruct.All
+func (cs *concurrentStack) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromTop(cs.ToSlice())(yield)
+	}
+}
+
+// Backward iterates over a snapshot taken under the read lock, see stackStruct.Backward
+func (cs *concurrentStack) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		allFromBottom(cs.ToSlice())(yield)
+	}
+}
+
+// Drain pops every element with TopAndPop, so each element is yielded to only one of
+// the goroutines draining the same stack
+func (cs *concurrentStack) Drain() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for {
+			elem, err := cs.TopAndPop()
+			if err != nil || !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// allFromTop returns an iterator over stack (stored bottom first) from the top to the bottom
+func allFromTop[T any](stack []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		stackSize := len(stack)
+		for i := stackSize - 1; i >= 0; i-- {
+			if !yield(stackSize-i, stack[i]) {
+				return
+			}
+		}
+	}
+}
+
+// allFromBottom returns an iterator over stack (stored bottom first) from the bottom to the top
+func allFromBottom[T any](stack []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		stackSize := len(stack)
+		for i := 0; i < stackSize; i++ {
+			if !yield(stackSize-i, stack[i]) {
+				return
+			}
+		}
+	}
+}
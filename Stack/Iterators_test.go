@@ -0,0 +1,86 @@
+package Stack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStackAllAndBackward(t *testing.T) {
+	st := Stack()
+	_ = st.Push("a", "b", "c")
+
+	var positions []int
+	var elems []interface{}
+	for position, elem := range st.All() {
+		positions = append(positions, position)
+		elems = append(elems, elem)
+		// the loop walks a snapshot
+		_ = st.Push("x")
+	}
+	if !reflect.DeepEqual(positions, []int{1, 2, 3}) || !reflect.DeepEqual(elems, []interface{}{"c", "b", "a"}) {
+		t.Fatalf("All() yielded %v %v, want [1 2 3] [c b a]", positions, elems)
+	}
+	_ = st.Pops(3)
+
+	positions, elems = nil, nil
+	for position, elem := range st.Backward() {
+		positions = append(positions, position)
+		elems = append(elems, elem)
+	}
+	if !reflect.DeepEqual(positions, []int{3, 2, 1}) || !reflect.DeepEqual(elems, []interface{}{"a", "b", "c"}) {
+		t.Fatalf("Backward() yielded %v %v, want [3 2 1] [a b c]", positions, elems)
+	}
+
+	count := 0
+	for range st.All() {
+		count++
+		break
+	}
+	for range st.Backward() {
+		count++
+		break
+	}
+	if count != 2 {
+		t.Fatalf("breaking out of the loops ran %d iterations, want 2", count)
+	}
+}
+
+func TestStackDrain(t *testing.T) {
+	st := New[int]()
+	for range st.Drain() {
+		t.Fatal("Drain() of an empty stack yielded an element")
+	}
+
+	st.Push(1, 2, 3)
+	var drained []int
+	for elem := range st.Drain() {
+		drained = append(drained, elem)
+		if elem == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(drained, []int{3, 2}) || !reflect.DeepEqual(st.ToSlice(), []int{1}) {
+		t.Fatalf("Drain() with a break yielded %v and left %v, want [3 2] and [1]", drained, st.ToSlice())
+	}
+
+	drained = nil
+	for elem := range st.Drain() {
+		drained = append(drained, elem)
+		if elem == 1 {
+			st.Push(5)
+		}
+	}
+	if !reflect.DeepEqual(drained, []int{1, 5}) || !st.Empty() {
+		t.Fatalf("Drain() yielded %v, want [1 5] including the element pushed in the loop", drained)
+	}
+
+	cs := NewConcurrent()
+	_ = cs.Push(1, 2)
+	drained = nil
+	for elem := range cs.Drain() {
+		drained = append(drained, elem.(int))
+	}
+	if !reflect.DeepEqual(drained, []int{2, 1}) || !cs.Empty() {
+		t.Fatalf("concurrent Drain() yielded %v, want [2 1]", drained)
+	}
+}
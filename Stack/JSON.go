@@ -0,0 +1,41 @@
+package Stack
+
+import "github.com/FahimSifnatul/goDataStructures/internal/Codec"
+
+func (st *stackStruct) MarshalJSON() ([]byte, error) {
+	return Codec.MarshalJSON(st.stackDataKind, st.stack)
+}
+
+func (st *stackStruct) UnmarshalJSON(data []byte) error {
+	kind, elems, err := Codec.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := st.checkCapacity(len(elems)); err != nil {
+		return err
+	}
+
+	decoded := Stack()
+	decoded.equal = st.equal
+	decoded.stackDataKind = kind
+	if err := decoded.Push(elems...); err != nil {
+		return err
+	}
+
+	st.stack = decoded.stack
+	st.stackDataKind = decoded.stackDataKind
+	return nil
+}
+
+func (cs *concurrentStack) MarshalJSON() ([]byte, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.MarshalJSON()
+}
+
+func (cs *concurrentStack) UnmarshalJSON(data []byte) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	defer cs.room.Broadcast()
+	return cs.st.UnmarshalJSON(data)
+}
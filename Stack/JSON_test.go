@@ -0,0 +1,108 @@
+package Stack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStackJSONRoundTrip(t *testing.T) {
+	st := Stack()
+	_ = st.Push(3, 1, 2)
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"int","elements":[3,1,2]}` {
+		t.Fatalf("Marshal() = %s", data)
+	}
+
+	decoded := Stack()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.ToSlice(); !reflect.DeepEqual(got, []interface{}{3, 1, 2}) || decoded.stackDataKind != reflect.Int {
+		t.Fatalf("decoded %v of kind %v, want [3 1 2] of kind int", got, decoded.stackDataKind)
+	}
+}
+
+func TestStackJSONEmptyAndCleared(t *testing.T) {
+	st := Stack()
+	_ = st.Push("a")
+	_ = st.Pop()
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"string","elements":[]}` {
+		t.Fatalf("Marshal() of an emptied stack = %s", data)
+	}
+	decoded := Stack()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Size() != 0 || decoded.stackDataKind != reflect.String {
+		t.Fatalf("decoded size %d and kind %v, want an empty string stack", decoded.Size(), decoded.stackDataKind)
+	}
+
+	st.Clear()
+	if data, err = json.Marshal(st); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"","elements":[]}` {
+		t.Fatalf("Marshal() of a cleared stack = %s", data)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.stackDataKind != reflect.Invalid {
+		t.Fatalf("decoded kind %v, want no kind", decoded.stackDataKind)
+	}
+}
+
+func TestStackJSONNamedType(t *testing.T) {
+	type ID int
+	st := Stack()
+	_ = st.Push(ID(7))
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Stack()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if top, _ := decoded.Top(); top != 7 {
+		t.Fatalf("decoded Top() = %#v, want int 7", top)
+	}
+}
+
+func TestStackJSONRejectsStructs(t *testing.T) {
+	type point struct{ X, Y int }
+	st := Stack()
+	_ = st.Push(point{1, 2})
+	if _, err := json.Marshal(st); err == nil {
+		t.Fatal("Marshal() of a struct stack succeeded, the document couldn't be decoded")
+	}
+}
+
+func TestStackJSONRejectsBadInput(t *testing.T) {
+	inputs := []string{
+		`not json`,
+		`{"kind":"int","elements":["a"]}`,
+		`{"kind":"int","elements":[1.5]}`,
+		`{"kind":"int8","elements":[300]}`,
+		`{"kind":"struct","elements":[]}`,
+		`{"kind":"","elements":[1]}`,
+	}
+	for _, input := range inputs {
+		st := Stack()
+		_ = st.Push(1, 2)
+		if err := json.Unmarshal([]byte(input), st); err == nil {
+			t.Fatalf("Unmarshal(%s) succeeded", input)
+		}
+		if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2}) || st.stackDataKind != reflect.Int {
+			t.Fatalf("a failed Unmarshal(%s) changed the stack to %v of kind %v", input, got, st.stackDataKind)
+		}
+	}
+}
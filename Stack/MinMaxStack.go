@@ -0,0 +1,219 @@
+package Stack
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+)
+
+// MinMaxStack a global function which creates, initializes and returns a min max stack instance
+// it only accepts elements of ordered data kinds i.e. ints, uints, floats and strings
+// elements are ordered like Codec.Less i.e. a NaN is less than any other float, so Min is NaN
+// while a NaN is on the stack and Max is NaN only if every element is NaN
+// it accepts the same Option values as Stack()
+func MinMaxStack(opts ...Option) *minMaxStack {
+	return &minMaxStack{
+		st:   Stack(opts...),
+		mins: make([]interface{}, 0),
+		maxs: make([]interface{}, 0),
+	}
+}
+
+// minMaxStack a stackStruct which keeps the minimum and the maximum of every prefix of the stack
+// mins[i] and maxs[i] are the minimum and the maximum of the elements from the bottom up to index i,
+// so popping only truncates them and Min and Max read the last ones in O(1)
+// elements are compared with Codec.Less
+type minMaxStack struct {
+	st   *stackStruct
+	mins []interface{}
+	maxs []interface{}
+}
+
+type minMaxStackMethods interface {
+	// Push adds one or more elements to the stack and updates the minimum and the maximum
+	// returns error if data types mismatched or the data kind isn't ordered and also doesn't push any value to the stack
+	// the overflow policies of WithCapacity work like they do on a stack, but dropping the bottom-most elements
+	// makes Push recompute every minimum and maximum in O(n)
+	Push(elem ...interface{}) error
+
+	// Pop, Pops, RemoveAll, Clear, Top, Tops, TopAndPop, TopsAndPops, Size, Empty, Search, Display and ToSlice
+	// work like the methods of stackStruct
+	Pop() error
+	Pops(popCount int) error
+	RemoveAll()
+	Clear()
+	Top() (interface{}, error)
+	Tops(topCount int) ([]interface{}, error)
+	TopAndPop() (interface{}, error)
+	TopsAndPops(count int) ([]interface{}, error)
+	Size() int
+	Empty() bool
+	Search(elem interface{}) int
+	Display()
+	ToSlice() []interface{}
+
+	// Min returns the smallest element of the stack in O(1)
+	// and error (if stack is empty)
+	Min() (interface{}, error)
+
+	// Max returns the largest element of the stack in O(1)
+	// and error (if stack is empty)
+	Max() (interface{}, error)
+
+	// private methods (for internal use only)
+
+	// track appends the minimum and the maximum of the stack having elem on its top
+	track(elem interface{})
+
+	// rebuild recomputes every minimum and maximum from the bottom of the stack
+	rebuild()
+
+	// trim drops the minimums and maximums of the popped elements
+	trim()
+}
+
+func (ms *minMaxStack) Push(elem ...interface{}) error {
+	for _, e := range elem {
+		if kind := reflect.TypeOf(e).Kind(); !isOrdered(kind) {
+			return &UnsupportedKindError{Kind: kind}
+		}
+	}
+
+	stackSize := ms.st.Size()
+	droppedBottom := ms.st.limit.Stats.DroppedOldest
+	if err := ms.st.Push(elem...); err != nil {
+		return err
+	}
+	if ms.st.limit.Stats.DroppedOldest != droppedBottom {
+		ms.rebuild()
+		return nil
+	}
+	if ms.st.Size() == stackSize {
+		// discarded by DropIncoming
+		return nil
+	}
+	for _, e := range elem {
+		ms.track(e)
+	}
+	return nil
+}
+
+func (ms *minMaxStack) Pop() error {
+	defer ms.trim()
+	return ms.st.Pop()
+}
+
+func (ms *minMaxStack) Pops(popCount int) error {
+	defer ms.trim()
+	return ms.st.Pops(popCount)
+}
+
+func (ms *minMaxStack) RemoveAll() {
+	ms.st.RemoveAll()
+	ms.trim()
+}
+
+func (ms *minMaxStack) Clear() {
+	ms.st.Clear()
+	ms.trim()
+}
+
+func (ms *minMaxStack) Top() (interface{}, error) {
+	return ms.st.Top()
+}
+
+func (ms *minMaxStack) Tops(topCount int) ([]interface{}, error) {
+	return ms.st.Tops(topCount)
+}
+
+func (ms *minMaxStack) TopAndPop() (interface{}, error) {
+	defer ms.trim()
+	return ms.st.TopAndPop()
+}
+
+func (ms *minMaxStack) TopsAndPops(count int) ([]interface{}, error) {
+	defer ms.trim()
+	return ms.st.TopsAndPops(count)
+}
+
+func (ms *minMaxStack) Size() int {
+	return ms.st.Size()
+}
+
+func (ms *minMaxStack) Empty() bool {
+	return ms.st.Empty()
+}
+
+func (ms *minMaxStack) Search(elem interface{}) int {
+	return ms.st.Search(elem)
+}
+
+func (ms *minMaxStack) Display() {
+	fmt.Println(ms.ToSlice())
+}
+
+func (ms *minMaxStack) ToSlice() []interface{} {
+	return ms.st.ToSlice()
+}
+
+func (ms *minMaxStack) Min() (interface{}, error) {
+	if ms.Empty() {
+		return nil, ErrEmpty
+	}
+	return ms.mins[len(ms.mins)-1], nil
+}
+
+func (ms *minMaxStack) Max() (interface{}, error) {
+	if ms.Empty() {
+		return nil, ErrEmpty
+	}
+	return ms.maxs[len(ms.maxs)-1], nil
+}
+
+func (ms *minMaxStack) track(elem interface{}) {
+	last := len(ms.mins) - 1
+	if last < 0 {
+		ms.mins = append(ms.mins, elem)
+		ms.maxs = append(ms.maxs, elem)
+		return
+	}
+
+	minElem, maxElem := ms.mins[last], ms.maxs[last]
+	if Codec.Less(elem, minElem) {
+		minElem = elem
+	}
+	if Codec.Less(maxElem, elem) {
+		maxElem = elem
+	}
+	ms.mins = append(ms.mins, minElem)
+	ms.maxs = append(ms.maxs, maxElem)
+}
+
+func (ms *minMaxStack) rebuild() {
+	ms.mins = ms.mins[:0]
+	ms.maxs = ms.maxs[:0]
+	for _, e := range ms.st.stack {
+		ms.track(e)
+	}
+}
+
+func (ms *minMaxStack) trim() {
+	stackSize := ms.st.Size()
+	clear(ms.mins[stackSize:])
+	clear(ms.maxs[stackSize:])
+	ms.mins = ms.mins[:stackSize]
+	ms.maxs = ms.maxs[:stackSize]
+}
+
+// isOrdered checks whether the given data kind is ordered i.e. ints, uints, floats and strings
+func isOrdered(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+package Stack
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkMinMax compares Min and Max with a scan of the stack
+func checkMinMax(t *testing.T, step int, ms *minMaxStack) {
+	t.Helper()
+	elems := ms.ToSlice()
+	if len(elems) == 0 {
+		if _, err := ms.Min(); !errors.Is(err, ErrEmpty) {
+			t.Fatalf("step %d: Min() of an empty stack = %v, want ErrEmpty", step, err)
+		}
+		if _, err := ms.Max(); !errors.Is(err, ErrEmpty) {
+			t.Fatalf("step %d: Max() of an empty stack = %v, want ErrEmpty", step, err)
+		}
+		return
+	}
+
+	wantMin, wantMax := elems[0].(int), elems[0].(int)
+	for _, e := range elems[1:] {
+		wantMin, wantMax = min(wantMin, e.(int)), max(wantMax, e.(int))
+	}
+	if got, _ := ms.Min(); got != wantMin {
+		t.Fatalf("step %d: Min() = %v, want %d for %v", step, got, wantMin, elems)
+	}
+	if got, _ := ms.Max(); got != wantMax {
+		t.Fatalf("step %d: Max() = %v, want %d for %v", step, got, wantMax, elems)
+	}
+}
+
+func testMinMaxStack(t *testing.T, opts ...Option) {
+	rng := rand.New(rand.NewSource(1))
+	ms := MinMaxStack(opts...)
+	for step := 0; step < 5000; step++ {
+		size := ms.Size()
+		switch rng.Intn(6) {
+		case 0, 1:
+			elems := make([]interface{}, rng.Intn(3)+1)
+			for i := range elems {
+				elems[i] = rng.Intn(100)
+			}
+			_ = ms.Push(elems...)
+		case 2:
+			_ = ms.Pop()
+		case 3:
+			if err := ms.Pops(rng.Intn(size + 1)); err != nil {
+				t.Fatal(err)
+			}
+		case 4:
+			_, _ = ms.TopAndPop()
+		case 5:
+			if _, err := ms.TopsAndPops(rng.Intn(size + 1)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		checkMinMax(t, step, ms)
+
+		// a rejected count keeps the minimum and the maximum
+		if _, err := ms.TopsAndPops(ms.Size() + 1); err == nil {
+			t.Fatalf("step %d: TopsAndPops beyond the size succeeded", step)
+		}
+		checkMinMax(t, step, ms)
+	}
+}
+
+func TestMinMaxStack(t *testing.T) {
+	testMinMaxStack(t)
+}
+
+func TestMinMaxStackDropBottom(t *testing.T) {
+	testMinMaxStack(t, WithCapacity(5, DropBottom))
+}
+
+func TestMinMaxStackDropIncoming(t *testing.T) {
+	testMinMaxStack(t, WithCapacity(5, DropIncoming))
+}
+
+func TestMinMaxStackDropsBottomExtreme(t *testing.T) {
+	ms := MinMaxStack(WithCapacity(3, DropBottom))
+	_ = ms.Push(1, 9, 5)
+	_ = ms.Push(4)
+	if got, _ := ms.Min(); got != 4 {
+		t.Fatalf("Min() = %v after the bottom 1 was dropped, want 4", got)
+	}
+	_ = ms.Push(3)
+	if got, _ := ms.Max(); got != 5 {
+		t.Fatalf("Max() = %v after the bottom 9 was dropped, want 5", got)
+	}
+}
+
+func TestMinMaxStackRejectsUnorderedKinds(t *testing.T) {
+	ms := MinMaxStack()
+	var unsupported *UnsupportedKindError
+	if err := ms.Push(1, true); !errors.As(err, &unsupported) {
+		t.Fatalf("Push(1, true) = %v, want an *UnsupportedKindError", err)
+	}
+	if !ms.Empty() {
+		t.Fatalf("a rejected Push left %v", ms.ToSlice())
+	}
+	_ = ms.Push("b", "a", "c")
+	if lo, _ := ms.Min(); lo != "a" {
+		t.Fatalf("Min() = %v, want a", lo)
+	}
+}
+
+func TestMinMaxStackNaN(t *testing.T) {
+	ms := MinMaxStack()
+	_ = ms.Push(math.NaN(), 2.0, -1.0, 5.0)
+	if lo, _ := ms.Min(); !math.IsNaN(lo.(float64)) {
+		t.Fatalf("Min() = %v with a NaN on the stack, want NaN", lo)
+	}
+	if hi, _ := ms.Max(); hi != 5.0 {
+		t.Fatalf("Max() = %v, want 5 as a NaN is less than any other float", hi)
+	}
+
+	// a NaN pushed on top doesn't change the maximum either
+	_ = ms.Push(math.NaN())
+	if hi, _ := ms.Max(); hi != 5.0 {
+		t.Fatalf("Max() = %v after pushing a NaN, want 5", hi)
+	}
+	_ = ms.Pops(4)
+	if lo, _ := ms.Min(); !math.IsNaN(lo.(float64)) {
+		t.Fatalf("Min() = %v with only a NaN on the stack, want NaN", lo)
+	}
+	if hi, _ := ms.Max(); !math.IsNaN(hi.(float64)) {
+		t.Fatalf("Max() = %v with only a NaN on the stack, want NaN", hi)
+	}
+}
@@ -0,0 +1,237 @@
+package Stack
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// Persistent a global function which creates, initializes and returns an empty persistent stack instance
+// it accepts the same Option values as Stack(), WithCapacity is ignored
+func Persistent(opts ...Option) *persistentStack {
+	st := Stack(opts...)
+	return &persistentStack{
+		equal: st.equal,
+	}
+}
+
+// stackNode a cons cell of a persistent stack, it is never changed once created
+// size is the number of elements from this node to the bottom
+type stackNode struct {
+	elem interface{}
+	next *stackNode
+	size int
+}
+
+// persistentStack an immutable stack stored as a cons list from the Top() to the bottom
+// Push and Pop return a new stack sharing the untouched nodes with the old one,
+// so every version stays valid and taking a snapshot is just keeping the pointer
+// it is safe to use from multiple goroutines as nothing is ever modified
+type persistentStack struct {
+	top           *stackNode
+	stackDataKind reflect.Kind
+	equal         func(a, b interface{}) bool
+}
+
+type persistentStackMethods interface {
+	// Push returns a new stack having the elements pushed on top of the caller stack in O(len(elem))
+	// returns error if data types mismatched, the caller stack is never changed
+	Push(elem ...interface{}) (*persistentStack, error)
+
+	// Pop returns a new stack without the Top() element of the caller stack in O(1)
+	// returns error if the stack is empty
+	Pop() (*persistentStack, error)
+
+	// Pops returns a new stack without the popCount top elements of the caller stack in O(popCount)
+	// returns error if the popCount number is negative or bigger than the size of the stack
+	Pops(popCount int) (*persistentStack, error)
+
+	// RemoveAll returns an empty stack keeping the data type of the caller stack
+	RemoveAll() *persistentStack
+
+	// Clear returns an empty stack without a data type
+	Clear() *persistentStack
+
+	// Top returns the top element i.e. last inserted element from the stack
+	// and error (if stack is empty)
+	Top() (interface{}, error)
+
+	// Tops returns top elements i.e. the latest elements equal to topCount (stored in a new slice)
+	// ordered like the Tops of stackStruct i.e. the Top() is the last one, and error (if any)
+	Tops(topCount int) ([]interface{}, error)
+
+	// TopAndPop returns the Top() element and the stack without it
+	// and error (if stack is empty)
+	TopAndPop() (interface{}, *persistentStack, error)
+
+	// Size returns the size of the stack in O(1)
+	Size() int
+
+	// Empty checks whether the stack is empty or not
+	Empty() bool
+
+	// Search finds the parametric element in the stack
+	// if the element is found then returns the position from the Top() else -1 (not found)
+	// N.B. Top() is taken as position 1
+	Search(elem interface{}) int
+
+	// Display prints the stack value as slice on console screen, the right most data is the Top()
+	Display()
+
+	// ToSlice returns the elements as a new slice from the bottom to the Top()
+	ToSlice() []interface{}
+
+	// All returns an iterator over the stack from the Top() to the bottom
+	// it yields the position of every element (Top() is taken as position 1, like Search) and the element
+	All() iter.Seq2[int, interface{}]
+
+	// ToStack returns a mutable stack having the same elements, data type and equal function
+	ToStack() *stackStruct
+}
+
+func (ps *persistentStack) Push(elem ...interface{}) (*persistentStack, error) {
+	probe := &stackStruct{stackDataKind: ps.stackDataKind, equal: ps.equal}
+	for _, e := range elem {
+		if err := probe.checkDataKind(e); err != nil {
+			return nil, err
+		}
+	}
+
+	pushed := &persistentStack{top: ps.top, stackDataKind: probe.stackDataKind, equal: ps.equal}
+	for _, e := range elem {
+		pushed.top = &stackNode{elem: e, next: pushed.top, size: pushed.Size() + 1}
+	}
+	return pushed, nil
+}
+
+func (ps *persistentStack) Pop() (*persistentStack, error) {
+	if ps.Empty() {
+		return nil, ErrEmpty
+	}
+
+	return &persistentStack{top: ps.top.next, stackDataKind: ps.stackDataKind, equal: ps.equal}, nil
+}
+
+func (ps *persistentStack) Pops(popCount int) (*persistentStack, error) {
+	stackSize := ps.Size()
+	if err := checkCount("pop", popCount, stackSize); err != nil {
+		return nil, err
+	}
+
+	top := ps.top
+	for i := 0; i < popCount; i++ {
+		top = top.next
+	}
+	return &persistentStack{top: top, stackDataKind: ps.stackDataKind, equal: ps.equal}, nil
+}
+
+func (ps *persistentStack) RemoveAll() *persistentStack {
+	return &persistentStack{stackDataKind: ps.stackDataKind, equal: ps.equal}
+}
+
+func (ps *persistentStack) Clear() *persistentStack {
+	return &persistentStack{equal: ps.equal}
+}
+
+func (ps *persistentStack) Top() (interface{}, error) {
+	if ps.Empty() {
+		return nil, ErrEmpty
+	}
+
+	return ps.top.elem, nil
+}
+
+func (ps *persistentStack) Tops(topCount int) ([]interface{}, error) {
+	stackSize := ps.Size()
+	if err := checkCount("top", topCount, stackSize); err != nil {
+		return nil, err
+	}
+
+	elemSlice := make([]interface{}, topCount)
+	node := ps.top
+	for i := topCount - 1; i >= 0; i-- {
+		elemSlice[i] = node.elem
+		node = node.next
+	}
+	return elemSlice, nil
+}
+
+func (ps *persistentStack) TopAndPop() (interface{}, *persistentStack, error) {
+	elem, err := ps.Top()
+	if err != nil {
+		return nil, nil, err
+	}
+	popped, err := ps.Pop()
+	if err != nil {
+		return nil, nil, err
+	}
+	return elem, popped, nil
+}
+
+func (ps *persistentStack) Size() int {
+	if ps.top == nil {
+		return 0
+	}
+	return ps.top.size
+}
+
+func (ps *persistentStack) Empty() bool {
+	return ps.Size() == 0
+}
+
+func (ps *persistentStack) Search(elem interface{}) int {
+	probe := &stackStruct{equal: ps.equal}
+	position := 1
+	for node := ps.top; node != nil; node = node.next {
+		if probe.isEqual(node.elem, elem) {
+			return position
+		}
+		position++
+	}
+	return -1
+}
+
+func (ps *persistentStack) Display() {
+	fmt.Println(ps.ToSlice())
+}
+
+func (ps *persistentStack) ToSlice() []interface{} {
+	elemSlice, _ := ps.Tops(ps.Size())
+	return elemSlice
+}
+
+func (ps *persistentStack) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		position := 1
+		for node := ps.top; node != nil; node = node.next {
+			if !yield(position, node.elem) {
+				return
+			}
+			position++
+		}
+	}
+}
+
+func (ps *persistentStack) ToStack() *stackStruct {
+	return &stackStruct{
+		stack:         ps.ToSlice(),
+		stackDataKind: ps.stackDataKind,
+		equal:         ps.equal,
+	}
+}
+
+// ToPersistent builds the cons list from the bottom in O(n), nothing is shared with the caller stack
+func (st *stackStruct) ToPersistent() *persistentStack {
+	ps := &persistentStack{stackDataKind: st.stackDataKind, equal: st.equal}
+	for _, e := range st.stack {
+		ps.top = &stackNode{elem: e, next: ps.top, size: ps.Size() + 1}
+	}
+	return ps
+}
+
+// ToPersistent takes the snapshot under the read lock
+func (cs *concurrentStack) ToPersistent() *persistentStack {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.st.ToPersistent()
+}
@@ -0,0 +1,115 @@
+package Stack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPersistentStackVersions(t *testing.T) {
+	base, err := Persistent().Push(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, _ := base.Push(3)
+	right, _ := base.Push(4, 5)
+	popped, _ := right.Pop()
+
+	versions := []struct {
+		name  string
+		stack *persistentStack
+		want  []interface{}
+	}{
+		{"base", base, []interface{}{1, 2}},
+		{"left", left, []interface{}{1, 2, 3}},
+		{"right", right, []interface{}{1, 2, 4, 5}},
+		{"popped", popped, []interface{}{1, 2, 4}},
+	}
+	for _, v := range versions {
+		if got := v.stack.ToSlice(); !reflect.DeepEqual(got, v.want) || v.stack.Size() != len(v.want) {
+			t.Fatalf("%s version is %v of size %d, want %v", v.name, got, v.stack.Size(), v.want)
+		}
+	}
+
+	// the derived versions share the nodes of base instead of copying them
+	if left.top.next != base.top || right.top.next.next != base.top || popped.top != right.top.next {
+		t.Fatal("derived versions don't share the nodes of the version they were made from")
+	}
+}
+
+func TestPersistentStackEmpty(t *testing.T) {
+	ps := Persistent()
+	if !ps.Empty() || ps.Size() != 0 {
+		t.Fatalf("new stack has size %d", ps.Size())
+	}
+	if _, err := ps.Top(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Top() = %v, want ErrEmpty", err)
+	}
+	if _, err := ps.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Pop() = %v, want ErrEmpty", err)
+	}
+	if _, _, err := ps.TopAndPop(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("TopAndPop() = %v, want ErrEmpty", err)
+	}
+	if _, err := ps.Pops(1); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("Pops(1) = %v, want ErrCountExceedsSize", err)
+	}
+	if got := ps.ToSlice(); len(got) != 0 {
+		t.Fatalf("ToSlice() = %v, want none", got)
+	}
+}
+
+func TestPersistentStackKind(t *testing.T) {
+	ps, _ := Persistent().Push(1)
+	var mismatch *KindMismatchError
+	if _, err := ps.Push(2, "a"); !errors.As(err, &mismatch) {
+		t.Fatalf("Push() = %v, want *KindMismatchError", err)
+	}
+	if _, err := ps.RemoveAll().Push("a"); !errors.As(err, &mismatch) {
+		t.Fatalf("Push() after RemoveAll() = %v, want *KindMismatchError", err)
+	}
+	if _, err := ps.Clear().Push("a"); err != nil {
+		t.Fatalf("Push() after Clear() = %v", err)
+	}
+	if got := ps.ToSlice(); !reflect.DeepEqual(got, []interface{}{1}) {
+		t.Fatalf("failed pushes changed the stack to %v", got)
+	}
+}
+
+func TestPersistentStackConversions(t *testing.T) {
+	st := Stack()
+	_ = st.Push(1, 2, 3)
+	ps := st.ToPersistent()
+	if got := ps.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("ToPersistent() = %v, want [1 2 3]", got)
+	}
+	if top, _ := ps.Top(); top != 3 {
+		t.Fatalf("Top() = %v, want 3", top)
+	}
+
+	// nothing is shared with the mutable stack
+	_ = st.Pop()
+	_ = st.Push(4)
+	if got := ps.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("changing the stack changed the persistent stack to %v", got)
+	}
+
+	back := ps.ToStack()
+	_ = back.Push(5)
+	if got := back.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3, 5}) {
+		t.Fatalf("ToStack() then Push(5) = %v, want [1 2 3 5]", got)
+	}
+	if got := ps.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("changing the converted stack changed the persistent stack to %v", got)
+	}
+	var mismatch *KindMismatchError
+	if err := back.Push("a"); !errors.As(err, &mismatch) {
+		t.Fatalf("ToStack() lost the data kind, Push(\"a\") = %v", err)
+	}
+
+	cs := NewConcurrent()
+	_ = cs.Push(7, 8)
+	if got := cs.ToPersistent().ToSlice(); !reflect.DeepEqual(got, []interface{}{7, 8}) {
+		t.Fatalf("concurrent ToPersistent() = %v, want [7 8]", got)
+	}
+}
@@ -1,37 +1,44 @@
 package Stack
 
 import (
-	"errors"
 	"fmt"
+	"iter"
 	"reflect"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Bounded"
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
 )
 
 // Stack a global function which creates, initializes and returns a stack instance
-func Stack() *stackStruct {
-	return &stackStruct{
+// optional Option values (e.g. WithEqual) can be passed to configure the stack
+func Stack(opts ...Option) *stackStruct {
+	st := &stackStruct{
 		stack: make([]interface{}, 0),
 	}
+	for _, opt := range opts {
+		opt(st)
+	}
+	return st
 }
 
-// not supported data kinds are stored here
-var (
-	invalidKind = []reflect.Kind{
-		reflect.Array,
-		reflect.Chan,
-		reflect.Func,
-		reflect.Interface,
-		reflect.Map,
-		reflect.Ptr,
-		reflect.Slice,
-		reflect.Struct,
-		reflect.UnsafePointer,
+// Option configures a stack created by Stack()
+type Option func(*stackStruct)
+
+// WithEqual lets the stack accept elements of any data kind, including the unsupported ones
+// e.g. structs having slice fields, pointers or maps
+// equal is used by Search to compare two elements instead of ==
+func WithEqual(equal func(a, b interface{}) bool) Option {
+	return func(st *stackStruct) {
+		st.equal = equal
 	}
-)
+}
 
 // stackStruct where stack data are stored
 type stackStruct struct {
 	stack         []interface{}
 	stackDataKind reflect.Kind
+	equal         func(a, b interface{}) bool
+	limit         Bounded.Limit
 }
 
 type stackMethods interface {
@@ -39,6 +46,7 @@ type stackMethods interface {
 
 	// Push adds one or more elements to an existing stack.
 	// returns error if data types mismatched and also doesn't push any value to the stack
+	// a stack created WithCapacity applies its OverflowPolicy when the elements don't fit
 	Push(elem ...interface{}) error
 
 	// Pop removes the top element i.e. last inserted element from the stack
@@ -46,7 +54,7 @@ type stackMethods interface {
 
 	// Pops can pop multiple elements from the top of the stack
 	// all you have to do is to provide an int type number i.e. how many element to pop
-	// returns error if the popCount number is bigger than the size of the stack
+	// returns error if the popCount number is negative or bigger than the size of the stack
 	Pops(popCount int) error
 
 	// RemoveAll it removes all elements from the caller stack
@@ -63,14 +71,14 @@ type stackMethods interface {
 	// suppose, data type of the caller stack is int
 	// now caller stack calls this function then
 	// it will remove all elements from the stack and
-	// any data except invalidKind types can be inserted for this stack
+	// any data except Codec.UnsupportedKinds types can be inserted for this stack
 	Clear()
 
 	// Top returns the top element i.e. last inserted element from the stack
 	// and error (if stack is empty)
 	Top() (interface{}, error)
 
-	// Tops returns top elements i.e. the latest elements equal to topCount (stored in a slice)
+	// Tops returns top elements i.e. the latest elements equal to topCount (stored in a new slice)
 	// and error (if stack is empty)
 	Tops(topCount int) ([]interface{}, error)
 
@@ -102,16 +110,79 @@ type stackMethods interface {
 	// and the right most data is the last inserted value
 	Display()
 
-	// ToSlice returns the stack as slice
+	// ToSlice returns a copy of the stack as slice
+	// changing the returned slice doesn't change the stack, use View to read the stack without copying
 	ToSlice() []interface{}
 
+	// View returns a read-only view of the stack which doesn't copy the elements
+	View() View[interface{}]
+
+	// MarshalJSON encodes the stack as a JSON object having the data kind and the elements
+	// e.g. {"kind":"int","elements":[1,2,3]}, the elements are ordered from the bottom to the Top()
+	// returns error if the data kind isn't supported by internal/Codec e.g. a struct
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON replaces the elements and the data kind of the stack with the decoded ones
+	// every element is validated like Push, so a malformed document is rejected and the stack is not changed
+	// an element is decoded as the builtin type of the data kind, so elements of a named type e.g. type ID int come back as int
+	UnmarshalJSON(data []byte) error
+
+	// MarshalBinary encodes the stack in the compact binary format of the package internal/Codec
+	// the encoded data has a versioned header with the data kind followed by the elements from the bottom to the Top()
+	MarshalBinary() ([]byte, error)
+
+	// UnmarshalBinary replaces the elements and the data kind of the stack with the decoded ones
+	// every element is validated like Push, so malformed data is rejected and the stack is not changed
+	UnmarshalBinary(data []byte) error
+
+	// GobEncode and GobDecode let encoding/gob use the binary format
+	GobEncode() ([]byte, error)
+	GobDecode(data []byte) error
+
+	// All returns an iterator over the stack from the Top() to the bottom
+	// it yields the position of every element (Top() is taken as position 1, like Search) and the element
+	// the iterator walks a snapshot taken when the iteration starts,
+	// so pushing to or popping from the stack inside the loop doesn't change what is yielded
+	All() iter.Seq2[int, interface{}]
+
+	// Backward returns an iterator over the stack from the bottom to the Top()
+	// it yields the same position and element pairs as All but in reverse order
+	// and walks a snapshot in the same way
+	Backward() iter.Seq2[int, interface{}]
+
+	// Drain returns an iterator which pops and yields the Top() element until the stack is empty
+	// or the loop stops, elements pushed inside the loop are drained too
+	Drain() iter.Seq[interface{}]
+
+	// Capacity returns the capacity set by WithCapacity, 0 or less means unbounded
+	Capacity() int
+
+	// Overflows returns how many elements the overflow policy rejected or dropped
+	Overflows() OverflowStats
+
+	// ToPersistent returns an immutable copy of the stack, see Persistent
+	ToPersistent() *persistentStack
+
 	// private methods (for internal use only)
 
+	// makeRoom applies the overflow policy before pushing count elements
+	// returns whether the elements are to be pushed and the error of a rejected push
+	makeRoom(count int) (bool, error)
+
+	// checkCapacity checks whether count decoded elements fit in the stack
+	checkCapacity(count int) error
+
+	// mustWait checks whether a Push of the elements has to wait for room under the Block policy
+	mustWait(elem []interface{}) bool
+
 	// checkDataKind checks the data kind of the elements of a stack
 	// when adding an element to a stack, at first the data kind is checked by this function
 	// the stack data kind is of type builtin reflect.Kind
 	// a stack must contain elements having same data kind
 	checkDataKind(value interface{}) error
+
+	// isEqual compares two elements using the equal function (if provided) else ==
+	isEqual(a, b interface{}) bool
 }
 
 func (st *stackStruct) Push(elem ...interface{}) error {
@@ -121,6 +192,9 @@ func (st *stackStruct) Push(elem ...interface{}) error {
 		}
 	}
 
+	if push, err := st.makeRoom(len(elem)); !push {
+		return err
+	}
 	for _, e := range elem {
 		st.stack = append(st.stack, e)
 	}
@@ -130,7 +204,7 @@ func (st *stackStruct) Push(elem ...interface{}) error {
 func (st *stackStruct) Pop() error {
 	stackSize := st.Size()
 	if stackSize == 0 {
-		return errors.New("invalid operation as stack is empty")
+		return ErrEmpty
 	}
 
 	st.stack = st.stack[:stackSize-1]
@@ -139,9 +213,8 @@ func (st *stackStruct) Pop() error {
 
 func (st *stackStruct) Pops(popCount int) error {
 	stackSize := st.Size()
-	if popCount > stackSize {
-		errMsg := "invalid operation as pop count (%d) is greater than the stack size(%d)"
-		return fmt.Errorf(errMsg, popCount, stackSize)
+	if err := checkCount("pop", popCount, stackSize); err != nil {
+		return err
 	}
 
 	st.stack = st.stack[:stackSize-popCount]
@@ -162,7 +235,7 @@ func (st *stackStruct) Clear() {
 func (st *stackStruct) Top() (interface{}, error) {
 	stackSize := st.Size()
 	if stackSize == 0 {
-		return nil, errors.New("invalid operation as stack is empty")
+		return nil, ErrEmpty
 	}
 
 	return st.stack[stackSize-1], nil
@@ -170,12 +243,11 @@ func (st *stackStruct) Top() (interface{}, error) {
 
 func (st *stackStruct) Tops(topCount int) ([]interface{}, error) {
 	stackSize := st.Size()
-	if topCount > stackSize {
-		errMsg := "invalid operation as top count (%d) is greater than the stack size(%d)"
-		return nil, fmt.Errorf(errMsg, topCount, stackSize)
+	if err := checkCount("top", topCount, stackSize); err != nil {
+		return nil, err
 	}
 
-	return st.stack[stackSize-topCount : stackSize], nil
+	return append([]interface{}(nil), st.stack[stackSize-topCount:stackSize]...), nil
 }
 
 func (st *stackStruct) TopAndPop() (interface{}, error) {
@@ -214,7 +286,7 @@ func (st *stackStruct) Empty() bool {
 func (st *stackStruct) Search(elem interface{}) int {
 	stackSize := st.Size()
 	for i := stackSize - 1; i >= 0; i-- {
-		if st.stack[i] == elem {
+		if st.isEqual(st.stack[i], elem) {
 			return stackSize - i
 		}
 	}
@@ -226,22 +298,27 @@ func (st *stackStruct) Display() {
 }
 
 func (st *stackStruct) ToSlice() []interface{} {
-	return st.stack
+	return append([]interface{}(nil), st.stack...)
 }
 
 func (st *stackStruct) checkDataKind(val interface{}) error {
 	valKind := reflect.TypeOf(val).Kind()
 
 	if st.stackDataKind != reflect.Invalid && st.stackDataKind != valKind {
-		return errors.New("invalid value type")
+		return &KindMismatchError{Expected: st.stackDataKind, Got: valKind}
 	}
 
-	for _, kind := range invalidKind {
-		if valKind == kind {
-			return fmt.Errorf("%v is not supported type for stack", valKind)
-		}
+	if st.equal == nil && !Codec.IsSupportedType(reflect.TypeOf(val)) {
+		return &UnsupportedKindError{Kind: valKind}
 	}
 
 	st.stackDataKind = valKind
 	return nil
 }
+
+func (st *stackStruct) isEqual(a, b interface{}) bool {
+	if st.equal != nil {
+		return st.equal(a, b)
+	}
+	return a == b
+}
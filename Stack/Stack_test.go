@@ -0,0 +1,121 @@
+package Stack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStackRejectsNegativeCount(t *testing.T) {
+	st := Stack()
+	_ = st.Push(1, 2, 3)
+	if err := st.Pops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Pops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := st.Tops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Tops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := st.TopsAndPops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("TopsAndPops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if err := st.Pops(4); !errors.Is(err, ErrCountExceedsSize) {
+		t.Fatalf("Pops(4) = %v, want ErrCountExceedsSize", err)
+	}
+	if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("rejected counts changed the stack to %v", got)
+	}
+}
+
+func TestGenericStackRejectsNegativeCount(t *testing.T) {
+	st := New[int]()
+	st.Push(1, 2, 3)
+	if err := st.Pops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Pops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := st.Tops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Tops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := st.TopsAndPops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("TopsAndPops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if got := st.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("rejected counts changed the stack to %v", got)
+	}
+}
+
+func TestPersistentStackRejectsNegativeCount(t *testing.T) {
+	ps, err := Persistent().Push(1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ps.Pops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Pops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if _, err := ps.Tops(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("Tops(-1) = %v, want ErrNegativeCount", err)
+	}
+	if ps.Size() != 3 {
+		t.Fatalf("rejected counts changed the stack size to %d", ps.Size())
+	}
+}
+
+func TestStackElementsComparedByEqual(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	if err := Stack().Push(record{ID: 1}); err == nil {
+		t.Fatal("Push() of a struct having a slice field succeeded without WithEqual")
+	}
+
+	st := Stack(WithEqual(func(a, b interface{}) bool {
+		return a.(record).ID == b.(record).ID
+	}))
+	if err := st.Push(record{1, []string{"a"}}, record{2, nil}, record{3, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.Search(record{ID: 2}); got != 2 {
+		t.Fatalf("Search() = %d, want 2", got)
+	}
+	if got := st.Search(record{ID: 4}); got != -1 {
+		t.Fatalf("Search() of a missing record = %d, want -1", got)
+	}
+}
+
+func TestStackPointerElements(t *testing.T) {
+	x, y := 1, 1
+	if err := Stack().Push(&x); err == nil {
+		t.Fatal("Push() of a pointer succeeded without WithEqual")
+	}
+
+	st := Stack(WithEqual(func(a, b interface{}) bool {
+		return *a.(*int) == *b.(*int)
+	}))
+	if err := st.Push(&x); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.Search(&y); got != 1 {
+		t.Fatalf("Search() of an equal pointee = %d, want 1", got)
+	}
+}
+
+func TestStackComparableElements(t *testing.T) {
+	type point struct{ X, Y int }
+	st := Stack()
+	if err := st.Push([2]int{1, 2}, [2]int{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if got := st.Search([2]int{1, 2}); got != 2 {
+		t.Fatalf("Search() of an array = %d, want 2", got)
+	}
+	if err := st.Push(point{}); err == nil {
+		t.Fatal("Push() of a struct onto an array stack succeeded")
+	}
+
+	// == panics on an interface holding a slice, so such structs need WithEqual
+	type boxed struct{ V interface{} }
+	var unsupported *UnsupportedKindError
+	if err := Stack().Push(boxed{[]int{1}}); !errors.As(err, &unsupported) {
+		t.Fatalf("Push() of a struct having an interface field = %v, want *UnsupportedKindError", err)
+	}
+}
@@ -0,0 +1,39 @@
+package Stack
+
+// View a read-only view of a stack which doesn't copy the elements
+// index 0 is the bottom i.e. first inserted element and index Len()-1 is the Top(), like ToSlice
+// the view reads the stack directly, so it always shows the current elements of the stack
+type View[T any] struct {
+	stack *[]T
+}
+
+func (st *stackStruct) View() View[interface{}] {
+	return View[interface{}]{stack: &st.stack}
+}
+
+func (st *genericStack[T]) View() View[T] {
+	return View[T]{stack: &st.stack}
+}
+
+// Len returns the number of elements in the stack
+func (v View[T]) Len() int {
+	return len(*v.stack)
+}
+
+// At returns the element at index i, it panics with a *ViewIndexError if i is out of range
+func (v View[T]) At(i int) T {
+	if i < 0 || i >= v.Len() {
+		panic(&ViewIndexError{Index: i, Len: v.Len()})
+	}
+	return (*v.stack)[i]
+}
+
+// Range calls f for every element from the bottom to the Top() until f returns false
+// the stack must not be changed by f
+func (v View[T]) Range(f func(i int, elem T) bool) {
+	for i, elem := range *v.stack {
+		if !f(i, elem) {
+			return
+		}
+	}
+}
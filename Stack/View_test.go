@@ -0,0 +1,63 @@
+package Stack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// atPanic returns the value At(i) panics with, nil if it doesn't panic
+func atPanic[T any](v View[T], i int) (recovered interface{}) {
+	defer func() { recovered = recover() }()
+	v.At(i)
+	return nil
+}
+
+func TestViewAtOutOfRange(t *testing.T) {
+	st := New[int]()
+	st.Push(1, 2, 3)
+	v := st.View()
+	if v.At(0) != 1 || v.At(2) != 3 {
+		t.Fatalf("At(0), At(2) = %d, %d, want 1, 3", v.At(0), v.At(2))
+	}
+	for _, i := range []int{-1, 3} {
+		var indexErr *ViewIndexError
+		err, _ := atPanic(v, i).(error)
+		if !errors.As(err, &indexErr) || indexErr.Index != i || indexErr.Len != 3 {
+			t.Fatalf("At(%d) panicked with %v, want a *ViewIndexError", i, err)
+		}
+	}
+	if got, want := atPanic(v, 3).(error).Error(), "Stack: view index 3 out of range [0, 3)"; got != want {
+		t.Fatalf("At(3) panicked with %q, want %q", got, want)
+	}
+}
+
+func TestStackSlicesAreCopies(t *testing.T) {
+	st := Stack()
+	_ = st.Push(1, 2, 3)
+	slice := st.ToSlice()
+	slice[0] = 9
+	_ = append(slice[:1], 8)
+	tops, _ := st.Tops(2)
+	tops[0], tops[1] = 7, 7
+	_ = append(tops[:1], 6)
+	if got := st.ToSlice(); !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("changing the returned slices changed the stack to %v", got)
+	}
+
+	gst := New[int]()
+	gst.Push(1, 2, 3)
+	gslice := gst.ToSlice()
+	gslice[2] = 9
+	_ = append(gslice[:1], 8)
+	gtops, _ := gst.Tops(2)
+	gtops[1] = 7
+	if got := gst.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("changing the returned slices changed the generic stack to %v", got)
+	}
+	// appending to the copy must not write into spare capacity of the stack either
+	gst.Push(4)
+	if top, _ := gst.Top(); top != 4 || !reflect.DeepEqual(gslice, []int{1, 8, 9}) {
+		t.Fatalf("the stack and a returned slice share storage: top %d, slice %v", top, gslice)
+	}
+}
@@ -0,0 +1,68 @@
+package Bounded
+
+// Policy decides what a push does when the container is at its capacity
+// the OverflowPolicy constants of Stack and Queue have the same values
+type Policy int
+
+const (
+	// Reject fails the push without pushing any element
+	Reject Policy = iota
+
+	// DropOldest removes as many earliest inserted elements as needed to push the new ones
+	DropOldest
+
+	// DropIncoming discards the pushed elements without an error
+	DropIncoming
+
+	// Block makes a concurrent container wait until there is room for every pushed element,
+	// anything else has no other goroutine to make room, so it behaves like Reject
+	Block
+)
+
+// Stats counts the elements handled by the overflow policy
+type Stats struct {
+	Rejected        int
+	DroppedOldest   int
+	DroppedIncoming int
+}
+
+// Limit a capacity, 0 or less meaning unbounded, with its overflow policy and the elements it handled so far
+type Limit struct {
+	Capacity int
+	Policy   Policy
+	Stats    Stats
+}
+
+// MakeRoom applies the policy to a push of count elements into a container holding size elements
+// returns how many of the earliest inserted elements the container must drop before pushing and whether
+// the push goes on, rejected is true if the push must fail as the elements don't fit
+func (l *Limit) MakeRoom(size, count int) (drop int, push bool, rejected bool) {
+	if l.Capacity <= 0 || size+count <= l.Capacity {
+		return 0, true, false
+	}
+
+	if count > l.Capacity || l.Policy == Reject || l.Policy == Block {
+		l.Stats.Rejected += count
+		return 0, false, true
+	}
+	if l.Policy == DropIncoming {
+		l.Stats.DroppedIncoming += count
+		return 0, false, false
+	}
+
+	// DropOldest
+	drop = size + count - l.Capacity
+	l.Stats.DroppedOldest += drop
+	return drop, true, false
+}
+
+// Fits reports whether count elements pushed at once can ever fit in the container
+func (l *Limit) Fits(count int) bool {
+	return l.Capacity <= 0 || count <= l.Capacity
+}
+
+// MustWait reports whether a push of count elements into a container holding size elements
+// has to wait for room under the Block policy, a push which can never fit doesn't wait
+func (l *Limit) MustWait(size, count int) bool {
+	return l.Policy == Block && l.Capacity > 0 && count <= l.Capacity && size+count > l.Capacity
+}
@@ -0,0 +1,52 @@
+package Bounded
+
+import "testing"
+
+func TestLimitMakeRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      Policy
+		size, count int
+		drop        int
+		push        bool
+		rejected    bool
+		stats       Stats
+	}{
+		{"fits", Reject, 1, 2, 0, true, false, Stats{}},
+		{"reject", Reject, 2, 2, 0, false, true, Stats{Rejected: 2}},
+		{"block", Block, 2, 2, 0, false, true, Stats{Rejected: 2}},
+		{"drop oldest", DropOldest, 2, 2, 1, true, false, Stats{DroppedOldest: 1}},
+		{"drop incoming", DropIncoming, 2, 2, 0, false, false, Stats{DroppedIncoming: 2}},
+		{"more than capacity", DropOldest, 0, 4, 0, false, true, Stats{Rejected: 4}},
+	}
+	for _, tt := range tests {
+		l := Limit{Capacity: 3, Policy: tt.policy}
+		drop, push, rejected := l.MakeRoom(tt.size, tt.count)
+		if drop != tt.drop || push != tt.push || rejected != tt.rejected || l.Stats != tt.stats {
+			t.Errorf("%s: MakeRoom() = %d, %v, %v with stats %+v, want %d, %v, %v with stats %+v",
+				tt.name, drop, push, rejected, l.Stats, tt.drop, tt.push, tt.rejected, tt.stats)
+		}
+	}
+
+	unbounded := Limit{Policy: Reject}
+	if drop, push, rejected := unbounded.MakeRoom(100, 100); drop != 0 || !push || rejected {
+		t.Fatalf("unbounded MakeRoom() = %d, %v, %v, want 0, true, false", drop, push, rejected)
+	}
+}
+
+func TestLimitMustWait(t *testing.T) {
+	l := Limit{Capacity: 3, Policy: Block}
+	if !l.MustWait(2, 2) {
+		t.Fatal("MustWait() of a push which doesn't fit yet = false")
+	}
+	if l.MustWait(1, 2) || l.MustWait(0, 4) {
+		t.Fatal("MustWait() of a push which fits or can never fit = true")
+	}
+	l.Policy = Reject
+	if l.MustWait(2, 2) {
+		t.Fatal("MustWait() under Reject = true")
+	}
+	if !l.Fits(3) || l.Fits(4) {
+		t.Fatal("Fits() doesn't compare with the capacity")
+	}
+}
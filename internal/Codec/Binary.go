@@ -0,0 +1,254 @@
+package Codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// BinaryVersion is the version of the binary format written by MarshalBinary
+//
+// the format is
+//
+//	version (1 byte) | data kind (1 byte) | element count (uvarint) | elements
+//
+// and every element is encoded according to the data kind
+//
+//	bool                      1 byte, 0 or 1
+//	int, int8, ..., int64     zig-zag varint
+//	uint, uint8, ..., uintptr uvarint
+//	float32, float64          IEEE 754 bits, 4 or 8 bytes little endian
+//	complex64, complex128     real part then imaginary part, as floats
+//	string                    length (uvarint) followed by the bytes
+const BinaryVersion = 1
+
+var (
+	errMalformed    = errors.New("binary data is truncated or malformed")
+	errOverflow     = errors.New("binary data has a value overflowing its data kind")
+	errNonCanonical = errors.New("binary data has a value which isn't encoded the way MarshalBinary encodes it")
+)
+
+// MarshalBinary encodes the elements having the given data kind in the binary format
+func MarshalBinary(kind reflect.Kind, elems []interface{}) ([]byte, error) {
+	if err := checkEncodable(kind, len(elems)); err != nil {
+		return nil, err
+	}
+
+	buf := []byte{BinaryVersion, byte(kind)}
+	buf = binary.AppendUvarint(buf, uint64(len(elems)))
+	for _, elem := range elems {
+		var err error
+		if buf, err = AppendElement(buf, elem); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes data written by MarshalBinary and returns its data kind and elements
+// every element is decoded as the type of the data kind (see KindType)
+// only the canonical encoding is accepted e.g. no overlong varint, so decoded data is encoded back to the same bytes
+func UnmarshalBinary(data []byte) (reflect.Kind, []interface{}, error) {
+	if len(data) < 2 {
+		return reflect.Invalid, nil, errMalformed
+	}
+	if data[0] != BinaryVersion {
+		return reflect.Invalid, nil, fmt.Errorf("unsupported binary format version %d", data[0])
+	}
+
+	kind := reflect.Kind(data[1])
+	count, n := binary.Uvarint(data[2:])
+	if n <= 0 {
+		return reflect.Invalid, nil, errMalformed
+	}
+	if n != uvarintSize(count) {
+		return reflect.Invalid, nil, errNonCanonical
+	}
+	data = data[2+n:]
+
+	if kind == reflect.Invalid {
+		if count != 0 || len(data) != 0 {
+			return reflect.Invalid, nil, errInvalidKindElements
+		}
+		return kind, nil, nil
+	}
+	if _, err := KindType(kind); err != nil {
+		return reflect.Invalid, nil, err
+	}
+	// every element takes at least one byte, so a bigger count can't be valid
+	if count > uint64(len(data)) {
+		return reflect.Invalid, nil, errMalformed
+	}
+
+	elems := make([]interface{}, count)
+	for i := range elems {
+		elem, n, err := DecodeElement(kind, data)
+		if err != nil {
+			return reflect.Invalid, nil, err
+		}
+		elems[i] = elem
+		data = data[n:]
+	}
+	if len(data) != 0 {
+		return reflect.Invalid, nil, fmt.Errorf("%d unexpected bytes after the last element", len(data))
+	}
+	return kind, elems, nil
+}
+
+// AppendElement appends the binary form of elem (see BinaryVersion) to buf and returns the extended buffer
+// returns error if the data kind of elem can't be encoded
+func AppendElement(buf []byte, elem interface{}) ([]byte, error) {
+	value := reflect.ValueOf(elem)
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(buf, value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(buf, value.Uint()), nil
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(value.Float()))), nil
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(value.Float())), nil
+	case reflect.Complex64:
+		c := value.Complex()
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(real(c))))
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(imag(c)))), nil
+	case reflect.Complex128:
+		c := value.Complex()
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(real(c)))
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(imag(c))), nil
+	case reflect.String:
+		buf = binary.AppendUvarint(buf, uint64(value.Len()))
+		return append(buf, value.String()...), nil
+	default:
+		return nil, fmt.Errorf("%v is not an encodable data kind", value.Kind())
+	}
+}
+
+// DecodeElement decodes one element of the given data kind from the start of data
+// returns the element (having the type of KindType(kind)) and the number of bytes read
+func DecodeElement(kind reflect.Kind, data []byte) (interface{}, int, error) {
+	typ, err := KindType(kind)
+	if err != nil {
+		return nil, 0, err
+	}
+	value := reflect.New(typ).Elem()
+
+	switch kind {
+	case reflect.Bool:
+		if len(data) < 1 {
+			return nil, 0, errMalformed
+		}
+		if data[0] > 1 {
+			return nil, 0, fmt.Errorf("invalid bool value %d", data[0])
+		}
+		value.SetBool(data[0] == 1)
+		return value.Interface(), 1, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, 0, errMalformed
+		}
+		if n != varintSize(i) {
+			return nil, 0, errNonCanonical
+		}
+		if value.OverflowInt(i) {
+			return nil, 0, errOverflow
+		}
+		value.SetInt(i)
+		return value.Interface(), n, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, 0, errMalformed
+		}
+		if n != uvarintSize(u) {
+			return nil, 0, errNonCanonical
+		}
+		if value.OverflowUint(u) {
+			return nil, 0, errOverflow
+		}
+		value.SetUint(u)
+		return value.Interface(), n, nil
+	case reflect.Float32:
+		if len(data) < 4 {
+			return nil, 0, errMalformed
+		}
+		f, err := decodeFloat32(binary.LittleEndian.Uint32(data))
+		if err != nil {
+			return nil, 0, err
+		}
+		value.SetFloat(float64(f))
+		return value.Interface(), 4, nil
+	case reflect.Float64:
+		if len(data) < 8 {
+			return nil, 0, errMalformed
+		}
+		value.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return value.Interface(), 8, nil
+	case reflect.Complex64:
+		if len(data) < 8 {
+			return nil, 0, errMalformed
+		}
+		re, err := decodeFloat32(binary.LittleEndian.Uint32(data))
+		if err != nil {
+			return nil, 0, err
+		}
+		im, err := decodeFloat32(binary.LittleEndian.Uint32(data[4:]))
+		if err != nil {
+			return nil, 0, err
+		}
+		value.SetComplex(complex(float64(re), float64(im)))
+		return value.Interface(), 8, nil
+	case reflect.Complex128:
+		if len(data) < 16 {
+			return nil, 0, errMalformed
+		}
+		re := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+		value.SetComplex(complex(re, im))
+		return value.Interface(), 16, nil
+	default: // reflect.String
+		length, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, 0, errMalformed
+		}
+		if n != uvarintSize(length) {
+			return nil, 0, errNonCanonical
+		}
+		if length > uint64(len(data)-n) {
+			return nil, 0, errMalformed
+		}
+		end := n + int(length)
+		value.SetString(string(data[n:end]))
+		return value.Interface(), end, nil
+	}
+}
+
+// decodeFloat32 returns the float32 having the given bits
+// returns error if the bits don't survive the float64 round trip of reflect.Value.SetFloat i.e. a signaling NaN
+func decodeFloat32(bits uint32) (float32, error) {
+	f := math.Float32frombits(bits)
+	if math.Float32bits(float32(float64(f))) != bits {
+		return 0, errNonCanonical
+	}
+	return f, nil
+}
+
+// uvarintSize returns the number of bytes of the shortest uvarint encoding of u
+func uvarintSize(u uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], u)
+}
+
+// varintSize returns the number of bytes of the shortest varint encoding of i
+func varintSize(i int64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutVarint(buf[:], i)
+}
@@ -0,0 +1,73 @@
+package Codec
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// errInvalidKindElements is returned when a document without data kind has elements
+var errInvalidKindElements = errors.New("elements found in a document without data kind")
+
+// Document is the JSON form of a container
+// Kind is the locked data kind (see KindName) and Elements are the elements in container order
+type Document struct {
+	Kind     string            `json:"kind"`
+	Elements []json.RawMessage `json:"elements"`
+}
+
+// MarshalJSON encodes the elements having the given data kind as a Document
+// returns error if the data kind can't be decoded (see KindType), so every encoded document decodes back
+func MarshalJSON(kind reflect.Kind, elems []interface{}) ([]byte, error) {
+	if err := checkEncodable(kind, len(elems)); err != nil {
+		return nil, err
+	}
+
+	doc := Document{
+		Kind:     KindName(kind),
+		Elements: make([]json.RawMessage, len(elems)),
+	}
+	for i, elem := range elems {
+		raw, err := json.Marshal(elem)
+		if err != nil {
+			return nil, err
+		}
+		doc.Elements[i] = raw
+	}
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes a Document and returns its data kind and elements
+// every element is decoded as the type of the document data kind (see KindType),
+// so an element of a named type e.g. type ID int is decoded as the builtin type of its data kind (int)
+func UnmarshalJSON(data []byte) (reflect.Kind, []interface{}, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return reflect.Invalid, nil, err
+	}
+
+	kind, err := ParseKind(doc.Kind)
+	if err != nil {
+		return reflect.Invalid, nil, err
+	}
+	if kind == reflect.Invalid {
+		if len(doc.Elements) != 0 {
+			return reflect.Invalid, nil, errInvalidKindElements
+		}
+		return kind, nil, nil
+	}
+
+	typ, err := KindType(kind)
+	if err != nil {
+		return reflect.Invalid, nil, err
+	}
+	elems := make([]interface{}, len(doc.Elements))
+	for i, raw := range doc.Elements {
+		value := reflect.New(typ)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			return reflect.Invalid, nil, err
+		}
+		elems[i] = value.Elem().Interface()
+	}
+	return kind, elems, nil
+}
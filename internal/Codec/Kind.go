@@ -0,0 +1,173 @@
+package Codec
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// kindTypes maps every data kind which can be encoded and decoded to the type used when decoding
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Uintptr:    reflect.TypeOf(uintptr(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+// UnsupportedKinds the data kinds which can't be stored in a container without an equal or key function
+// (see IsSupportedType for the exceptions)
+var UnsupportedKinds = []reflect.Kind{
+	reflect.Array,
+	reflect.Chan,
+	reflect.Func,
+	reflect.Interface,
+	reflect.Map,
+	reflect.Ptr,
+	reflect.Slice,
+	reflect.Struct,
+	reflect.UnsafePointer,
+}
+
+// IsSupportedType reports whether elements of type typ can be stored without an equal or key function
+// UnsupportedKinds types are not supported except structs and arrays which are comparable with ==
+// and have no interface field or element, as == panics on an interface holding e.g. a slice
+func IsSupportedType(typ reflect.Type) bool {
+	valKind := typ.Kind()
+	if (valKind == reflect.Struct || valKind == reflect.Array) && typ.Comparable() {
+		return !hasInterface(typ)
+	}
+	return !slices.Contains(UnsupportedKinds, valKind)
+}
+
+// hasInterface reports whether a value of type typ holds an interface in one of its fields or elements, at any depth
+func hasInterface(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Array:
+		return hasInterface(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if hasInterface(typ.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KindName returns the name used for kind in encoded documents
+// reflect.Invalid i.e. the data kind of a container which never had an element is the empty name
+func KindName(kind reflect.Kind) string {
+	if kind == reflect.Invalid {
+		return ""
+	}
+	return kind.String()
+}
+
+// ParseKind returns the data kind named by name (see KindName)
+// returns error if the kind can't be decoded
+func ParseKind(name string) (reflect.Kind, error) {
+	if name == "" {
+		return reflect.Invalid, nil
+	}
+	for kind := range kindTypes {
+		if kind.String() == name {
+			return kind, nil
+		}
+	}
+	return reflect.Invalid, fmt.Errorf("%q is not a decodable data kind", name)
+}
+
+// checkEncodable returns error if elements having the given data kind can't be encoded in a form which decodes back
+// i.e. the data kind isn't decodable or elements (count > 0) have no data kind
+func checkEncodable(kind reflect.Kind, count int) error {
+	if kind == reflect.Invalid {
+		if count != 0 {
+			return errInvalidKindElements
+		}
+		return nil
+	}
+	_, err := KindType(kind)
+	return err
+}
+
+// KindType returns the type used to decode elements of the given data kind
+// returns error if the kind can't be decoded e.g. structs, whose concrete type is unknown
+func KindType(kind reflect.Kind) (reflect.Type, error) {
+	typ, ok := kindTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("%v is not a decodable data kind", kind)
+	}
+	return typ, nil
+}
+
+// Sort sorts elems having the same data kind in ascending order
+// numbers are ordered by value (NaN before any other float), strings lexicographically and false before true,
+// complex numbers by real part then imaginary part
+// elements of any other kind are ordered by their %v representation, so the order is still deterministic
+func Sort(elems []interface{}) {
+	sort.SliceStable(elems, func(i, j int) bool {
+		return Less(elems[i], elems[j])
+	})
+}
+
+// Less reports whether a is ordered before b, a and b must have the same data kind (see Sort)
+func Less(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch va.Kind() {
+	case reflect.Bool:
+		return !va.Bool() && vb.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() < vb.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() < vb.Uint()
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(va.Float(), vb.Float()) < 0
+	case reflect.Complex64, reflect.Complex128:
+		ca, cb := va.Complex(), vb.Complex()
+		if c := compareFloats(real(ca), real(cb)); c != 0 {
+			return c < 0
+		}
+		return compareFloats(imag(ca), imag(cb)) < 0
+	case reflect.String:
+		return va.String() < vb.String()
+	default:
+		return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+	}
+}
+
+// compareFloats compares like cmp.Compare i.e. NaN before any other float
+// and also orders NaNs by their bits, so the order of any elements is deterministic
+func compareFloats(a, b float64) int {
+	if math.IsNaN(a) && math.IsNaN(b) {
+		return cmp.Compare(math.Float64bits(a), math.Float64bits(b))
+	}
+	return cmp.Compare(a, b)
+}
+
+// CheckSorted returns error if elems (having the same data kind) aren't in ascending order (see Sort)
+func CheckSorted(elems []interface{}) error {
+	for i := 1; i < len(elems); i++ {
+		if Less(elems[i], elems[i-1]) {
+			return fmt.Errorf("element %d is less than the element before it", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package Codec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSupportedType(t *testing.T) {
+	type point struct{ X, Y int }
+	type named struct {
+		Name string
+		At   point
+	}
+	type withInterface struct{ V interface{} }
+	type nestedInterface struct{ Inner [2]withInterface }
+	type withSlice struct{ S []int }
+
+	tests := []struct {
+		value     interface{}
+		supported bool
+	}{
+		{1, true},
+		{"a", true},
+		{point{}, true},
+		{named{}, true},
+		{[3]int{}, true},
+		{[2]point{}, true},
+		{withInterface{}, false},
+		{nestedInterface{}, false},
+		{[1]interface{}{}, false},
+		{[1]withInterface{}, false},
+		{withSlice{}, false},
+		{[]int{}, false},
+		{map[int]int{}, false},
+		{&point{}, false},
+		{func() {}, false},
+	}
+	for _, tt := range tests {
+		if got := IsSupportedType(reflect.TypeOf(tt.value)); got != tt.supported {
+			t.Errorf("IsSupportedType(%T) = %v, want %v", tt.value, got, tt.supported)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package CodecTest
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/FahimSifnatul/goDataStructures/internal/Codec"
+)
+
+// Container the methods of a container encoded in the binary format of internal/Codec
+type Container interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// seeds the element lists encoded as the valid seeds, every list has a single data kind
+var seeds = [][]interface{}{
+	{},
+	{1, -2, 300, math.MaxInt64, math.MinInt64},
+	{"a", "", "hello, 世界"},
+	{1.5, math.Inf(-1), math.NaN(), -0.0},
+	{true, false},
+	{uint8(0), uint8(255)},
+	{complex64(1 + 2i), complex64(-1)},
+}
+
+// FuzzUnmarshalBinary fuzzes UnmarshalBinary of the containers created by newContainer
+// add stores elements in a container e.g. Push or Add
+// data which decodes must encode back to the same bytes (the encoding is canonical),
+// data which doesn't decode must leave the container as it was
+// the seeds are the encodings of containers holding elements of every kind and invalid data derived from them:
+// truncated data, an unknown version, an undecodable data kind and bytes after the last element
+func FuzzUnmarshalBinary[C Container](f *testing.F, newContainer func() C, add func(c C, elem ...interface{}) error) {
+	encode := func(elems []interface{}) []byte {
+		c := newContainer()
+		if err := add(c, elems...); err != nil {
+			f.Fatal(err)
+		}
+		data, err := c.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		return data
+	}
+
+	for _, elems := range seeds {
+		f.Add(encode(elems))
+	}
+
+	valid := encode(seeds[1])
+	invalid := [][]byte{
+		nil,
+		valid[:1],
+		valid[:len(valid)-1],
+		append([]byte{Codec.BinaryVersion + 1}, valid[1:]...),
+		append([]byte{valid[0], byte(reflect.Struct)}, valid[2:]...),
+		append([]byte{valid[0], 0xff}, valid[2:]...),
+		append(append([]byte(nil), valid...), 0),
+	}
+	for _, data := range invalid {
+		if err := newContainer().UnmarshalBinary(data); err == nil {
+			f.Fatalf("UnmarshalBinary(%x) of an invalid seed succeeded", data)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c := newContainer()
+		if err := add(c, "kept", "elements"); err != nil {
+			t.Fatal(err)
+		}
+		before, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := c.UnmarshalBinary(data); err != nil {
+			after, err := c.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(after, before) {
+				t.Fatalf("a failed UnmarshalBinary(%x) changed the container from %x to %x", data, before, after)
+			}
+			return
+		}
+		encoded, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() after decoding %x: %v", data, err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("decoded %x re-encodes to %x", data, encoded)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package Ring
+
+// minCapacity is the smallest backing array a ring keeps once it has grown
+const minCapacity = 8
+
+// Ring a growable circular buffer
+// elements live in buf[head], buf[head+1], ... wrapping around the end of buf,
+// so pushing and popping at both ends are amortized O(1)
+// the buffer doubles when full and halves when only a quarter of it is used,
+// so memory stays bounded by the number of elements and popped elements are never kept reachable
+type Ring[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// New creates and returns an empty ring
+func New[T any]() *Ring[T] {
+	return &Ring[T]{}
+}
+
+// Len returns the number of elements in the ring
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// At returns the i-th element from the front, front is index 0
+// i must be in [0, Len())
+func (r *Ring[T]) At(i int) T {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+// Front returns the first element
+// the ring must not be empty
+func (r *Ring[T]) Front() T {
+	return r.buf[r.head]
+}
+
+// Back returns the last element
+// the ring must not be empty
+func (r *Ring[T]) Back() T {
+	return r.At(r.size - 1)
+}
+
+// PushBack appends elem after the last element
+func (r *Ring[T]) PushBack(elem T) {
+	r.grow()
+	r.buf[(r.head+r.size)%len(r.buf)] = elem
+	r.size++
+}
+
+// PushFront inserts elem before the first element
+func (r *Ring[T]) PushFront(elem T) {
+	r.grow()
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.head] = elem
+	r.size++
+}
+
+// PopFront removes and returns the first element
+// the ring must not be empty
+func (r *Ring[T]) PopFront() T {
+	elem := r.Front()
+	r.PopFronts(1)
+	return elem
+}
+
+// PopBack removes and returns the last element
+// the ring must not be empty
+func (r *Ring[T]) PopBack() T {
+	elem := r.Back()
+	r.PopBacks(1)
+	return elem
+}
+
+// PopFronts removes count elements from the front
+// count must be in [0, Len()]
+func (r *Ring[T]) PopFronts(count int) {
+	var zero T
+	for i := 0; i < count; i++ {
+		r.buf[r.head] = zero
+		r.head = (r.head + 1) % len(r.buf)
+	}
+	r.size -= count
+	r.shrink()
+}
+
+// PopBacks removes count elements from the back
+// count must be in [0, Len()]
+func (r *Ring[T]) PopBacks(count int) {
+	var zero T
+	for i := 0; i < count; i++ {
+		r.size--
+		r.buf[(r.head+r.size)%len(r.buf)] = zero
+	}
+	r.shrink()
+}
+
+// Slice copies count elements starting at the i-th element from the front to a new slice
+// i and count must satisfy 0 <= i, 0 <= count and i+count <= Len()
+func (r *Ring[T]) Slice(i, count int) []T {
+	elems := make([]T, count)
+	for j := range elems {
+		elems[j] = r.At(i + j)
+	}
+	return elems
+}
+
+// Clear removes every element and releases the backing array
+func (r *Ring[T]) Clear() {
+	*r = Ring[T]{}
+}
+
+// grow doubles the backing array if it is full
+func (r *Ring[T]) grow() {
+	if r.size < len(r.buf) {
+		return
+	}
+	r.resize(max(2*len(r.buf), minCapacity))
+}
+
+// shrink halves the backing array while at most a quarter of it is used
+func (r *Ring[T]) shrink() {
+	capacity := len(r.buf)
+	for capacity > minCapacity && r.size <= capacity/4 {
+		capacity /= 2
+	}
+	if capacity != len(r.buf) {
+		r.resize(capacity)
+	}
+}
+
+// resize moves the elements to a new backing array of the given capacity
+func (r *Ring[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	for i := 0; i < r.size; i++ {
+		buf[i] = r.At(i)
+	}
+	r.buf = buf
+	r.head = 0
+}
@@ -0,0 +1,66 @@
+package Ring
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRingMatchesSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	r := New[int]()
+	want := make([]int, 0)
+	for step := 0; step < 10000; step++ {
+		switch op := rng.Intn(6); {
+		case op == 0:
+			r.PushBack(step)
+			want = append(want, step)
+		case op == 1:
+			r.PushFront(step)
+			want = append([]int{step}, want...)
+		case op == 2 && len(want) > 0:
+			if got := r.PopFront(); got != want[0] {
+				t.Fatalf("step %d: PopFront() = %d, want %d", step, got, want[0])
+			}
+			want = want[1:]
+		case op == 3 && len(want) > 0:
+			if got := r.PopBack(); got != want[len(want)-1] {
+				t.Fatalf("step %d: PopBack() = %d, want %d", step, got, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		case op == 4:
+			count := rng.Intn(len(want) + 1)
+			r.PopFronts(count)
+			want = want[count:]
+		case op == 5:
+			count := rng.Intn(len(want) + 1)
+			r.PopBacks(count)
+			want = want[:len(want)-count]
+		}
+
+		if r.Len() != len(want) {
+			t.Fatalf("step %d: Len() = %d, want %d", step, r.Len(), len(want))
+		}
+		if got := r.Slice(0, r.Len()); !reflect.DeepEqual(got, want) && len(want) > 0 {
+			t.Fatalf("step %d: ring is %v, want %v", step, got, want)
+		}
+		if len(r.buf) > minCapacity && r.size <= len(r.buf)/4 {
+			t.Fatalf("step %d: %d elements kept in a backing array of %d", step, r.size, len(r.buf))
+		}
+	}
+}
+
+func TestRingReleasesPoppedElements(t *testing.T) {
+	r := New[*int]()
+	for i := 0; i < 5; i++ {
+		r.PushBack(new(int))
+	}
+	r.PopFront()
+	r.PopBacks(2)
+	for i := range r.buf {
+		inside := (i-r.head+len(r.buf))%len(r.buf) < r.size
+		if !inside && r.buf[i] != nil {
+			t.Fatalf("popped element still referenced at buf[%d]", i)
+		}
+	}
+}